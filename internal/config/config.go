@@ -3,6 +3,7 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
 
 	"github.com/joho/godotenv"
 )
@@ -26,6 +27,40 @@ type Config struct {
 	CLOUDINARY_CLOUD_NAME string
 	CLOUDINARY_API_KEY    string
 	CLOUDINARY_API_SECRET string
+
+	// Media storage: "cloudinary" (default), "local" or "memory"
+	MediaBackend  string
+	MediaLocalDir string // local backend: where files are written
+	MediaBaseURL  string // local backend: public URL prefix, its path is mounted as a static route
+
+	// Product image upload checks
+	ImageMaxBytes  int
+	ImageMinWidth  int
+	ImageMinHeight int
+	ImageMaxWidth  int // larger images are rejected before decoding, 0 = no limit
+	ImageMaxHeight int
+
+	// Soft-deleted products older than this are hard-deleted by the trash purge
+	TrashRetentionDays int
+
+	// How often "frequently bought together" pairs are rebuilt, 0 disables the background refresh
+	CoPurchaseRefreshMins int
+
+	// Purchase limits (max_per_customer) count a customer's orders from the last N days, 0 = all time
+	PurchaseLimitDays int
+
+	// How long starting checkout holds the cart's stock
+	CheckoutHoldMinutes int
+
+	// Low-stock alert threshold given to new products unless the admin sets one
+	LowStockThreshold int
+
+	// Back-in-stock emails go out RestockBatchSize at a time, one batch every RestockBatchSecs (0 disables)
+	RestockBatchSize int
+	RestockBatchSecs int
+
+	// Guest carts untouched for this many days are deleted (0 keeps them)
+	GuestCartTTLDays int
 }
 
 // Global variable to hold the loaded config
@@ -52,5 +87,38 @@ func LoadConfig() {
 		CLOUDINARY_CLOUD_NAME: os.Getenv("CLOUDINARY_CLOUD_NAME"),
 		CLOUDINARY_API_KEY:    os.Getenv("CLOUDINARY_API_KEY"),
 		CLOUDINARY_API_SECRET: os.Getenv("CLOUDINARY_API_SECRET"),
+		MediaBackend:          envString("MEDIA_BACKEND", "cloudinary"),
+		MediaLocalDir:         envString("MEDIA_LOCAL_DIR", "./uploads"),
+		MediaBaseURL:          envString("MEDIA_BASE_URL", "/media"),
+		ImageMaxBytes:         envInt("IMAGE_MAX_BYTES", 8<<20),
+		ImageMinWidth:         envInt("IMAGE_MIN_WIDTH", 500),
+		ImageMinHeight:        envInt("IMAGE_MIN_HEIGHT", 500),
+		ImageMaxWidth:         envInt("IMAGE_MAX_WIDTH", 8000),
+		ImageMaxHeight:        envInt("IMAGE_MAX_HEIGHT", 8000),
+		TrashRetentionDays:    envInt("TRASH_RETENTION_DAYS", 30),
+		CoPurchaseRefreshMins: envInt("COPURCHASE_REFRESH_MINUTES", 60),
+		PurchaseLimitDays:     envInt("PURCHASE_LIMIT_DAYS", 30),
+		CheckoutHoldMinutes:   envInt("CHECKOUT_HOLD_MINUTES", 10),
+		LowStockThreshold:     envInt("LOW_STOCK_THRESHOLD", 5),
+		RestockBatchSize:      envInt("RESTOCK_BATCH_SIZE", 50),
+		RestockBatchSecs:      envInt("RESTOCK_BATCH_SECONDS", 60),
+		GuestCartTTLDays:      envInt("GUEST_CART_TTL_DAYS", 30),
+	}
+}
+
+// envString reads an env var, falling back to def when unset
+func envString(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+// envInt reads an integer env var, falling back to def when unset or invalid
+func envInt(key string, def int) int {
+	v, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return def
 	}
+	return v
 }
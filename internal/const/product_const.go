@@ -0,0 +1,46 @@
+package constent
+
+// PriceBucket is a price range used by the listing facets (Max 0 = no upper bound)
+type PriceBucket struct {
+	Key   string
+	Label string
+	Min   int64
+	Max   int64
+}
+
+// price ranges shown in the storefront filter sidebar, in display order
+var PriceBuckets = []PriceBucket{
+	{Key: "under_2500", Label: "Under 2,500", Min: 0, Max: 2500},
+	{Key: "2500_5000", Label: "2,500 - 5,000", Min: 2500, Max: 5000},
+	{Key: "5000_10000", Label: "5,000 - 10,000", Min: 5000, Max: 10000},
+	{Key: "10000_20000", Label: "10,000 - 20,000", Min: 10000, Max: 20000},
+	{Key: "20000_plus", Label: "20,000 and above", Min: 20000, Max: 0},
+}
+
+// Image derivative sizes generated for every uploaded product image
+const (
+	ImageSizeThumbnail = "thumbnail" // cart, order history
+	ImageSizeCard      = "card"      // listing, wishlist
+	ImageSizeZoom      = "zoom"      // product page zoom
+)
+
+// ImageSizeWidths is the target width in px of each derivative (height keeps the aspect ratio)
+var ImageSizeWidths = map[string]int{
+	ImageSizeThumbnail: 200,
+	ImageSizeCard:      600,
+	ImageSizeZoom:      1600,
+}
+
+// Entity types stored in slug_redirects
+const (
+	SlugEntityProduct  = "product"
+	SlugEntityCategory = "category"
+)
+
+// What stock_movements.reference_id points at
+const (
+	StockRefOrderItem   = "order_item"
+	StockRefProduct     = "product"
+	StockRefVariant     = "variant"
+	StockRefRaffleEntry = "raffle_entry"
+)
@@ -6,20 +6,26 @@ import (
 
 	"github.com/akhilnasimk/SS_backend/internal/dto"
 	"github.com/akhilnasimk/SS_backend/internal/helpers"
+	"github.com/akhilnasimk/SS_backend/internal/middlewares"
 	"github.com/akhilnasimk/SS_backend/internal/services"
 	"github.com/akhilnasimk/SS_backend/utils/jwt"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 type AuthController struct {
 	authService services.AuthService
 	OtpService  services.OtpService
+	RVService   services.RecentlyViewedService
+	CartService services.CartService
 }
 
-func NewAuthController(service services.AuthService, OtpS services.OtpService) *AuthController {
+func NewAuthController(service services.AuthService, OtpS services.OtpService, RVS services.RecentlyViewedService, CartS services.CartService) *AuthController {
 	return &AuthController{
 		authService: service,
 		OtpService:  OtpS,
+		RVService:   RVS,
+		CartService: CartS,
 	}
 }
 
@@ -44,7 +50,7 @@ func (r *AuthController) Register(ctx *gin.Context) {
 	}
 
 	// Call service
-	err := r.authService.Register(user)
+	created, err := r.authService.Register(user)
 	if err != nil {
 		// Check for user already exists error
 		if err.Error() == "user with email "+user.Email+" already exists" {
@@ -61,6 +67,9 @@ func (r *AuthController) Register(ctx *gin.Context) {
 		return
 	}
 
+	// Keep what was collected as a guest (cart, recently viewed)
+	r.mergeGuestSession(ctx, created.ID)
+
 	// Success
 	ctx.JSON(http.StatusCreated, gin.H{
 		"message": "user has been registered successfully",
@@ -123,6 +132,9 @@ func (r *AuthController) Login(ctx *gin.Context) {
 		true,
 	)
 
+	// Move anything collected as a guest onto the account
+	r.mergeGuestSession(ctx, user.ID)
+
 	// Response
 	// Send user info in response
 	userInfo := gin.H{
@@ -139,6 +151,27 @@ func (r *AuthController) Login(ctx *gin.Context) {
 	})
 }
 
+// mergeGuestSession moves the guest's cart and recently viewed products to the user and drops the guest cookie
+func (r *AuthController) mergeGuestSession(ctx *gin.Context, userID uuid.UUID) {
+	guestID, ok := middlewares.GuestID(ctx)
+	if !ok {
+		return
+	}
+
+	// Errors are logged but don't fail the login; the cookie is kept so the next login retries
+	if err := r.CartService.MergeGuestCart(guestID, userID); err != nil {
+		log.Printf("failed to merge guest cart into user %s: %v", userID, err)
+		return
+	}
+
+	if err := r.RVService.MergeGuest(guestID, userID); err != nil {
+		log.Printf("failed to merge guest history into user %s: %v", userID, err)
+		return
+	}
+
+	middlewares.ClearGuestSession(ctx)
+}
+
 func (r *AuthController) Logout(ctx *gin.Context) {
 	// 1. Get refresh token from cookie
 	refreshToken, err := ctx.Cookie("refresh_token")
@@ -167,6 +200,9 @@ func (r *AuthController) RefreshToken(ctx *gin.Context) {
 	// Generate new access and refresh tokens
 	accessToken, newRefreshToken, err := r.authService.RefreshTokens(refresh)
 	if err != nil {
+		// drop the dead tokens so guest routes stop asking this browser to refresh
+		ctx.SetCookie("access_token", "", -1, "/", "", false, true)
+		ctx.SetCookie("refresh_token", "", -1, "/", "", false, true)
 		ctx.JSON(http.StatusUnauthorized, gin.H{"message": "Failed to refresh tokens", "error": err.Error()})
 		return
 	}
@@ -1,7 +1,6 @@
 package controllers
 
 import (
-	"fmt"
 	"net/http"
 
 	"github.com/akhilnasimk/SS_backend/internal/dto"
@@ -23,23 +22,10 @@ func NewCartController(cartService services.CartService) *CartController {
 	}
 }
 
-// GetUserCart handles GET /cart
+// GetUserCart handles GET /cart, for the logged-in user or the guest
 func (c *CartController) GetUserCart(ctx *gin.Context) {
-	// Get userID from context
-	userIDValue, exists := ctx.Get("UserID")
-	if !exists {
-		ctx.JSON(http.StatusUnauthorized, response.Failure("User not authenticated", nil))
-		return
-	}
-
-	userID := helpers.StringToUUID(userIDValue.(string))
-	if userID == uuid.Nil {
-		ctx.JSON(http.StatusBadRequest, response.Failure("Invalid user ID", nil))
-		return
-	}
-
 	// Call service
-	cartResponse, err := c.CartService.GetUserCartItems(userID)
+	cartResponse, err := c.CartService.GetUserCartItems(ctx.GetString("UserID"), ctx.GetString("GuestID"))
 	if err != nil {
 		// If record not found → return empty cart instead of failing
 		if err.Error() == "record not found" {
@@ -65,25 +51,21 @@ func (c *CartController) AddToCart(ctx *gin.Context) {
 		return
 	}
 
-	//  Extract user ID from context (middleware sets it as string)
-	userIDStr, exists := ctx.Get("UserID")
-	fmt.Println("user Id is ", userIDStr)
-	if !exists {
-		ctx.JSON(http.StatusUnauthorized, response.Failure("Unauthorized user", nil))
-		return
-	}
-
-	// Convert userID (string) → UUID using your helper
-	userID := helpers.StringToUUID(userIDStr.(string))
-	if userID == uuid.Nil {
-		ctx.JSON(http.StatusBadRequest, response.Failure("Invalid user ID", nil))
-		return
+	// Optional size selection (?variant_id=)
+	var variantID *uuid.UUID
+	if variantStr := ctx.Query("variant_id"); variantStr != "" {
+		id := helpers.StringToUUID(variantStr)
+		if id == uuid.Nil {
+			ctx.JSON(http.StatusBadRequest, response.Failure("Invalid variant ID", nil))
+			return
+		}
+		variantID = &id
 	}
 
 	// Service Layer call
-	orderitem, err := c.CartService.AddItemToCart(userID, productID)
+	orderitem, err := c.CartService.AddItemToCart(ctx.GetString("UserID"), ctx.GetString("GuestID"), productID, variantID)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.Failure("Failed to add product to cart", err.Error()))
+		ctx.JSON(purchaseLimitStatus(err, http.StatusInternalServerError), response.Failure("Failed to add product to cart", err.Error()))
 		return
 	}
 
@@ -115,8 +97,8 @@ func (c *CartController) UpdateCount(ctx *gin.Context) {
 	}
 
 	//  Call service
-	if err := c.CartService.IncOrDecCartItem(cartItemId, opStr); err != nil {
-		ctx.JSON(400, response.Failure("failed to update quantity", err.Error()))
+	if err := c.CartService.IncOrDecCartItem(ctx.GetString("UserID"), ctx.GetString("GuestID"), cartItemId, opStr); err != nil {
+		ctx.JSON(purchaseLimitStatus(err, 400), response.Failure("failed to update quantity", err.Error()))
 		return
 	}
 
@@ -132,7 +114,7 @@ func (c *CartController) DeleteCartItem(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.CartService.DeleteCartItem(id); err != nil {
+	if err := c.CartService.DeleteCartItem(ctx.GetString("UserID"), ctx.GetString("GuestID"), id); err != nil {
 		ctx.JSON(400, response.Failure("failed to delete the cart Items ", err.Error()))
 		return
 	}
@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/akhilnasimk/SS_backend/internal/dto"
+	"github.com/akhilnasimk/SS_backend/internal/services"
+	"github.com/akhilnasimk/SS_backend/utils/response"
+	"github.com/gin-gonic/gin"
+)
+
+type CategoryController struct {
+	CService services.CategoryService
+}
+
+func NewCategoryController(service services.CategoryService) CategoryController {
+	return CategoryController{
+		CService: service,
+	}
+}
+
+func (c *CategoryController) GetCategoryTree(ctx *gin.Context) {
+	tree, err := c.CService.GetCategoryTree()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.Failure("failed to fetch categories", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("categories fetched successfully", tree))
+}
+
+func (c *CategoryController) GetCategory(ctx *gin.Context) {
+	category, err := c.CService.GetCategory(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(categoryErrorStatus(err), response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("category fetched successfully", category))
+}
+
+// GetCategoryBySlug answers an old (renamed) slug with a 301 to the current one
+func (c *CategoryController) GetCategoryBySlug(ctx *gin.Context) {
+	category, redirectTo, err := c.CService.GetCategoryBySlug(ctx.Param("slug"))
+	if err != nil {
+		ctx.JSON(categoryErrorStatus(err), response.Failure(err.Error(), nil))
+		return
+	}
+
+	if redirectTo != "" {
+		redirectToSlug(ctx, redirectTo)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("category fetched successfully", category))
+}
+
+func (c *CategoryController) CreateCategory(ctx *gin.Context) {
+	var req dto.CategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Failure("invalid request body", err.Error()))
+		return
+	}
+
+	category, err := c.CService.CreateCategory(req)
+	if err != nil {
+		ctx.JSON(categoryErrorStatus(err), response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.Success("category created successfully", category))
+}
+
+func (c *CategoryController) UpdateCategory(ctx *gin.Context) {
+	var req dto.CategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Failure("invalid request body", err.Error()))
+		return
+	}
+
+	category, err := c.CService.UpdateCategory(ctx.Param("id"), req)
+	if err != nil {
+		ctx.JSON(categoryErrorStatus(err), response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("category updated successfully", category))
+}
+
+// DeleteCategory refuses categories that still hold products unless ?reassign_to=<category id> is given
+func (c *CategoryController) DeleteCategory(ctx *gin.Context) {
+	err := c.CService.DeleteCategory(ctx.Param("id"), ctx.Query("reassign_to"))
+	if err != nil {
+		ctx.JSON(categoryErrorStatus(err), response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("category deleted successfully", nil))
+}
+
+// categoryErrorStatus maps category service errors to HTTP status codes
+func categoryErrorStatus(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "not found"):
+		return http.StatusNotFound
+	case strings.Contains(msg, "already exists"), strings.Contains(msg, "still has"), strings.Contains(msg, "duplicate key"):
+		return http.StatusConflict
+	case strings.Contains(msg, "invalid"), strings.Contains(msg, "cannot"), strings.Contains(msg, "must"), strings.Contains(msg, "required"):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -2,6 +2,8 @@ package controllers
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/akhilnasimk/SS_backend/internal/dto"
 	"github.com/akhilnasimk/SS_backend/internal/helpers"
@@ -25,6 +27,24 @@ func (C *OrderController) GetAllOrders(ctx *gin.Context) {
 	id, exist := ctx.Get("UserID")
 	if !exist {
 		ctx.JSON(400, response.Failure("id not availabel", nil))
+		return
+	}
+
+	// Keyset mode: ?cursor= (empty for the first page) then follow next_cursor
+	if cursor, ok := ctx.GetQuery("cursor"); ok {
+		limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+		orders, next, err := C.OrderService.GetOrdersByCursor(id.(string), limit, cursor)
+		if err != nil {
+			ctx.JSON(400, response.Failure("Failed to get the orders ", err.Error()))
+			return
+		}
+
+		ctx.JSON(200, response.Success("order fetch sucess", gin.H{
+			"orders":      orders,
+			"next_cursor": next,
+		}))
+		return
 	}
 
 	orders, err := C.OrderService.GetAllOrders(id.(string))
@@ -65,13 +85,14 @@ func (C *OrderController) AddSingleItemOrder(ctx *gin.Context) {
 	orderRes, err := C.OrderService.CreateSingleOrder(
 		userID,
 		productID,
+		orderReq.VariantID,
 		orderReq.Quantity,
 		orderReq.ShippingAddress,
 		orderReq.PaymentMethod,
 	)
 
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.Failure("failed to place order", err.Error()))
+		ctx.JSON(purchaseLimitStatus(err, http.StatusInternalServerError), response.Failure("failed to place order", err.Error()))
 		return
 	}
 
@@ -99,19 +120,56 @@ func (C *OrderController) AddCartOrder(ctx *gin.Context) {
 	)
 
 	if err != nil {
-		ctx.JSON(500, response.Failure("failed to create order from cart", err.Error()))
+		ctx.JSON(purchaseLimitStatus(err, 500), response.Failure("failed to create order from cart", err.Error()))
 		return
 	}
 
 	ctx.JSON(201, response.Success("order created from cart", orderRes))
 }
 
+// StartCheckout - POST /order/checkout holds the cart's stock until the order is placed
+func (c *OrderController) StartCheckout(ctx *gin.Context) {
+	userID, exists := ctx.Get("UserID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, response.Failure("user id missing", nil))
+		return
+	}
+
+	holds, err := c.OrderService.StartCheckout(userID.(string))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case strings.Contains(err.Error(), "insufficient stock"),
+			strings.Contains(err.Error(), "released by raffle"):
+			status = http.StatusConflict
+		case strings.Contains(err.Error(), "cart is empty"),
+			strings.Contains(err.Error(), "select a size"),
+			strings.Contains(err.Error(), "not released"),
+			strings.Contains(err.Error(), "no longer available"),
+			strings.Contains(err.Error(), "inactive"):
+			status = http.StatusBadRequest
+		}
+		ctx.JSON(status, response.Failure("failed to start checkout", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("cart stock held for checkout", holds))
+}
+
 // CancelOrderItem - DELETE /orders/items/:item_id/cancel
 func (c *OrderController) CancelOrderItem(ctx *gin.Context) {
 	itemID := ctx.Param("item_id")
 
+	// the canceller is recorded in the stock ledger
+	userIDRaw, exists := ctx.Get("UserID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, response.Failure("Unauthorized", nil))
+		return
+	}
+	userID := helpers.StringToUUID(userIDRaw.(string))
+
 	// Call service (service validates the ID)
-	if err := c.OrderService.CancelSingleOrderItem(itemID); err != nil {
+	if err := c.OrderService.CancelSingleOrderItem(itemID, userID); err != nil {
 		// Check error type for appropriate status code
 		ctx.JSON(http.StatusBadRequest, response.Failure(err.Error(), nil))
 		return
@@ -178,3 +236,13 @@ func (c *OrderController) UpdateOrderStatus(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, response.Success("Order status updated", nil))
 }
+
+// purchaseLimitStatus answers 409 when max_per_customer was hit or the product is raffled,
+// otherwise the caller's status
+func purchaseLimitStatus(err error, fallback int) int {
+	if strings.Contains(err.Error(), "purchase limit exceeded") ||
+		strings.Contains(err.Error(), "released by raffle") {
+		return http.StatusConflict
+	}
+	return fallback
+}
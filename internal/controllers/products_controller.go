@@ -2,11 +2,15 @@ package controllers
 
 import (
 	"fmt"
+	"log"
 	"net/http"
+	"net/url"
+	"path"
 	"strconv"
 	"strings"
 
 	"github.com/akhilnasimk/SS_backend/internal/dto"
+	"github.com/akhilnasimk/SS_backend/internal/enums"
 	"github.com/akhilnasimk/SS_backend/internal/helpers"
 	"github.com/akhilnasimk/SS_backend/internal/services"
 	"github.com/akhilnasimk/SS_backend/utils/response"
@@ -15,12 +19,14 @@ import (
 )
 
 type ProductController struct {
-	PService services.ProductsService
+	PService  services.ProductsService
+	RVService services.RecentlyViewedService
 }
 
-func NewProductController(service services.ProductsService) ProductController {
+func NewProductController(service services.ProductsService, recentlyViewed services.RecentlyViewedService) ProductController {
 	return ProductController{
-		PService: service,
+		PService:  service,
+		RVService: recentlyViewed,
 	}
 }
 
@@ -30,6 +36,12 @@ func (c *ProductController) GetAllProducts(ctx *gin.Context) {
 
 	categoryID := ctx.Query("category_id")
 	search := ctx.Query("search")
+	// newest (default), price_asc, price_desc, name, best_selling, top_rated, relevance (with search)
+	sort := enums.ProductSort(ctx.DefaultQuery("sort", string(enums.SortNewest)))
+	if !sort.IsValid() {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid sort value (allowed: newest, price_asc, price_desc, name, best_selling, top_rated, relevance)"})
+		return
+	}
 	minPriceStr := ctx.Query("min_price")
 	maxPriceStr := ctx.Query("max_price")
 
@@ -51,7 +63,32 @@ func (c *ProductController) GetAllProducts(ctx *gin.Context) {
 	}
 
 	fmt.Println("the role is :", userRole)
-	products, total, err := c.PService.GetAllProducts(page, limit, categoryID, search, minPrice, maxPrice, role)
+
+	// Keyset mode: ?cursor= (empty for the first page) then follow next_cursor
+	if cursor, ok := ctx.GetQuery("cursor"); ok {
+		products, next, facets, err := c.PService.GetProductsByCursor(cursor, limit, categoryID, search, minPrice, maxPrice, sort, role)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if strings.Contains(err.Error(), "cursor") {
+				status = http.StatusBadRequest
+			}
+			ctx.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+
+		resp := gin.H{
+			"products":    products,
+			"next_cursor": next,
+			"limit":       limit,
+		}
+		if facets != nil {
+			resp["facets"] = facets
+		}
+		ctx.JSON(http.StatusOK, resp)
+		return
+	}
+
+	products, total, facets, err := c.PService.GetAllProducts(page, limit, categoryID, search, minPrice, maxPrice, sort, role)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -62,22 +99,93 @@ func (c *ProductController) GetAllProducts(ctx *gin.Context) {
 		"total":    total,
 		"page":     page,
 		"limit":    limit,
+		"facets":   facets,
 	})
 }
 
 func (R *ProductController) GetProductById(ctx *gin.Context) {
 	id := ctx.Param("id")
 
-	product, err := R.PService.GetProductById(id)
+	product, err := R.PService.GetProductById(id, ctx.GetString("UserRole"))
 
 	if err != nil {
 		ctx.JSON(400, response.Failure("failed to get the product", err.Error()))
 		return
 	}
 
+	R.recordView(ctx, id)
+
 	ctx.JSON(200, response.Success("product has fetched", product))
 }
 
+// recordView adds the product to the customer's (or guest's) recently viewed list; admins are not tracked
+func (c *ProductController) recordView(ctx *gin.Context, productID string) {
+	if ctx.GetString("UserRole") == "admin" {
+		return
+	}
+
+	if err := c.RVService.RecordView(ctx.GetString("UserID"), ctx.GetString("GuestID"), productID); err != nil {
+		log.Printf("failed to record product view: %v", err)
+	}
+}
+
+// GetProductBySlug serves the product page by slug; an old (renamed) slug gets a 301 to the current one
+func (R *ProductController) GetProductBySlug(ctx *gin.Context) {
+	product, redirectTo, err := R.PService.GetProductBySlug(ctx.Param("slug"), ctx.GetString("UserRole"))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, response.Failure("failed to get the product", err.Error()))
+		return
+	}
+
+	if redirectTo != "" {
+		redirectToSlug(ctx, redirectTo)
+		return
+	}
+
+	R.recordView(ctx, product.ID.String())
+
+	ctx.JSON(http.StatusOK, response.Success("product has fetched", product))
+}
+
+// GetUpcomingDrops lists unreleased products with release times and countdowns, ?limit= (default 10)
+func (R *ProductController) GetUpcomingDrops(ctx *gin.Context) {
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	drops, err := R.PService.GetUpcomingDrops(limit)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.Failure("failed to fetch upcoming drops", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("upcoming drops fetched", drops))
+}
+
+// redirectToSlug answers with 301 and a Location that swaps the last path segment for the new slug
+func redirectToSlug(ctx *gin.Context, slug string) {
+	location := path.Join(path.Dir(ctx.Request.URL.Path), url.PathEscape(slug))
+	ctx.Header("Location", location)
+	ctx.JSON(http.StatusMovedPermanently, response.Success("moved permanently", gin.H{
+		"slug":     slug,
+		"location": location,
+	}))
+}
+
+// adminActor is the logged-in admin, recorded as the actor of stock changes
+func adminActor(ctx *gin.Context) uuid.UUID {
+	return helpers.StringToUUID(ctx.GetString("UserID"))
+}
+
+// isProductInputError tells bad uploads / SEO fields (400) apart from server failures
+func isProductInputError(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "invalid image") ||
+		strings.Contains(msg, "invalid slug") ||
+		strings.Contains(msg, "invalid meta") ||
+		strings.Contains(msg, "invalid schedule") ||
+		strings.Contains(msg, "invalid max_per_customer") ||
+		strings.Contains(msg, "invalid low_stock_threshold")
+}
+
 // Uploading product withn cloudinery
 func (c *ProductController) UploadProduct(ctx *gin.Context) {
 	// fmt.Println("Content-Type:", ctx.ContentType())
@@ -132,10 +240,33 @@ func (c *ProductController) UploadProduct(ctx *gin.Context) {
 
 	fmt.Printf("Received %d files\n", len(files))
 
+	// Optional SEO fields, the slug is generated from the name when empty
+	seo := dto.ProductSEO{
+		Slug:            ctx.PostForm("slug"),
+		MetaTitle:       ctx.PostForm("meta_title"),
+		MetaDescription: ctx.PostForm("meta_description"),
+	}
+
+	// Optional drop window (RFC 3339)
+	schedule := dto.ProductSchedule{
+		ReleaseAt: ctx.PostForm("release_at"),
+		EndsAt:    ctx.PostForm("ends_at"),
+	}
+
+	// Optional per-customer purchase cap and low-stock alert threshold
+	limits := dto.ProductLimits{
+		MaxPerCustomer:    ctx.PostForm("max_per_customer"),
+		LowStockThreshold: ctx.PostForm("low_stock_threshold"),
+	}
+
 	// Call service
-	product, err := c.PService.CreateProduct(name, description, price, stockCount, categoryID, files)
+	product, err := c.PService.CreateProduct(name, description, price, stockCount, categoryID, files, seo, schedule, limits, adminActor(ctx))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.Failure(fmt.Sprintf("failed to create product: %v", err), nil))
+		status := http.StatusInternalServerError
+		if isProductInputError(err) {
+			status = http.StatusBadRequest
+		}
+		ctx.JSON(status, response.Failure(fmt.Sprintf("failed to create product: %v", err), nil))
 		return
 	}
 
@@ -234,8 +365,12 @@ func (c *ProductController) UpdateProduct(ctx *gin.Context) {
 	fmt.Println("final req before service call:", req)
 
 	// Call service
-	if err := c.PService.UpdateProduct(id, req); err != nil {
-		ctx.JSON(http.StatusInternalServerError, response.Failure("Failed to update product", err.Error()))
+	if err := c.PService.UpdateProduct(id, req, adminActor(ctx)); err != nil {
+		status := http.StatusInternalServerError
+		if isProductInputError(err) {
+			status = http.StatusBadRequest
+		}
+		ctx.JSON(status, response.Failure("Failed to update product", err.Error()))
 		return
 	}
 
@@ -280,3 +415,219 @@ func (c *ProductController) DeleteProduct(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "product deleted successfully"})
 }
+
+func (c *ProductController) RestoreProduct(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	if err := c.PService.RestoreProduct(id); err != nil {
+		if strings.Contains(err.Error(), "invalid product ID") {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if strings.Contains(err.Error(), "product not found") {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to restore product"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "product restored successfully"})
+}
+
+func (c *ProductController) GetTrash(ctx *gin.Context) {
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	products, total, err := c.PService.GetTrash(page, limit)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to fetch deleted products"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"products": products,
+		"total":    total,
+		"page":     page,
+		"limit":    limit,
+	})
+}
+
+func (c *ProductController) PurgeTrash(ctx *gin.Context) {
+	purged, err := c.PService.PurgeTrash()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to purge deleted products"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "trash purged", "purged": purged})
+}
+
+// ImportProducts takes a CSV upload (form field "file"). It is a dry run unless ?dry_run=false.
+func (c *ProductController) ImportProducts(ctx *gin.Context) {
+	dryRun, err := strconv.ParseBool(ctx.DefaultQuery("dry_run", "true"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Failure("dry_run must be true or false", nil))
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Failure("csv file is required (form field \"file\")", nil))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Failure("failed to read csv file", err.Error()))
+		return
+	}
+	defer file.Close()
+
+	report, err := c.PService.ImportProductsCSV(file, dryRun, adminActor(ctx))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if strings.Contains(err.Error(), "invalid csv") {
+			status = http.StatusBadRequest
+		}
+		ctx.JSON(status, response.Failure(err.Error(), report))
+		return
+	}
+
+	if len(report.Errors) > 0 {
+		ctx.JSON(http.StatusUnprocessableEntity, response.Failure("csv has invalid rows, nothing was saved", report))
+		return
+	}
+
+	message := "csv is valid, nothing was saved (dry run)"
+	if !dryRun {
+		message = "products imported successfully"
+	}
+	ctx.JSON(http.StatusOK, response.Success(message, report))
+}
+
+func (c *ProductController) ExportProducts(ctx *gin.Context) {
+	ctx.Header("Content-Type", "text/csv")
+	ctx.Header("Content-Disposition", "attachment; filename=products.csv")
+
+	if err := c.PService.ExportProductsCSV(ctx.Writer); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to export products"})
+		return
+	}
+}
+
+// ---------------- images ----------------
+
+func (c *ProductController) ReorderImages(ctx *gin.Context) {
+	var req dto.ReorderImagesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Failure("invalid request body", err.Error()))
+		return
+	}
+
+	images, err := c.PService.ReorderImages(ctx.Param("id"), req.ImageIDs)
+	if err != nil {
+		ctx.JSON(imageErrorStatus(err), response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("images reordered successfully", images))
+}
+
+func (c *ProductController) SetPrimaryImage(ctx *gin.Context) {
+	images, err := c.PService.SetPrimaryImage(ctx.Param("id"), ctx.Param("image_id"))
+	if err != nil {
+		ctx.JSON(imageErrorStatus(err), response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("primary image updated successfully", images))
+}
+
+func (c *ProductController) UpdateImageAltText(ctx *gin.Context) {
+	var req dto.UpdateImageAltTextRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Failure("invalid request body", err.Error()))
+		return
+	}
+
+	images, err := c.PService.UpdateImageAltText(ctx.Param("id"), ctx.Param("image_id"), req.AltText)
+	if err != nil {
+		ctx.JSON(imageErrorStatus(err), response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("alt text updated successfully", images))
+}
+
+// imageErrorStatus maps image service errors to HTTP status codes
+func imageErrorStatus(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "invalid"):
+		return http.StatusBadRequest
+	case strings.Contains(msg, "not found"):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ---------------- variants ----------------
+
+func (c *ProductController) GetVariants(ctx *gin.Context) {
+	variants, err := c.PService.GetVariants(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(variantErrorStatus(err), response.Failure("failed to fetch variants", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("variants fetched successfully", variants))
+}
+
+func (c *ProductController) AddVariant(ctx *gin.Context) {
+	var req dto.VariantRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Failure("Invalid request data", err.Error()))
+		return
+	}
+
+	variant, err := c.PService.AddVariant(ctx.Param("id"), req, adminActor(ctx))
+	if err != nil {
+		ctx.JSON(variantErrorStatus(err), response.Failure("failed to add variant", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.Success("variant added successfully", variant))
+}
+
+func (c *ProductController) UpdateVariant(ctx *gin.Context) {
+	var req dto.VariantRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Failure("Invalid request data", err.Error()))
+		return
+	}
+
+	variant, err := c.PService.UpdateVariant(ctx.Param("id"), ctx.Param("variant_id"), req, adminActor(ctx))
+	if err != nil {
+		ctx.JSON(variantErrorStatus(err), response.Failure("failed to update variant", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("variant updated successfully", variant))
+}
+
+// maps variant service errors to http status codes
+func variantErrorStatus(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "invalid product ID"), strings.Contains(msg, "invalid variant ID"):
+		return http.StatusBadRequest
+	case strings.Contains(msg, "not found"):
+		return http.StatusNotFound
+	case strings.Contains(msg, "duplicate key"):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
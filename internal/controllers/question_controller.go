@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/akhilnasimk/SS_backend/internal/dto"
+	"github.com/akhilnasimk/SS_backend/internal/services"
+	"github.com/akhilnasimk/SS_backend/utils/response"
+	"github.com/gin-gonic/gin"
+)
+
+type QuestionController struct {
+	QService services.QuestionService
+}
+
+func NewQuestionController(service services.QuestionService) QuestionController {
+	return QuestionController{
+		QService: service,
+	}
+}
+
+// GetProductQuestions lists a product's Q&A thread, newest questions first
+func (c *QuestionController) GetProductQuestions(ctx *gin.Context) {
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	questions, err := c.QService.GetProductQuestions(ctx.Param("id"), page, limit)
+	if err != nil {
+		ctx.JSON(questionErrorStatus(err), response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("questions fetched successfully", questions))
+}
+
+func (c *QuestionController) AskQuestion(ctx *gin.Context) {
+	userID, exists := ctx.Get("UserID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, response.Failure("user not authorized", nil))
+		return
+	}
+
+	var req dto.QuestionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Failure("invalid request body", err.Error()))
+		return
+	}
+
+	question, err := c.QService.AskQuestion(userID.(string), ctx.Param("id"), req)
+	if err != nil {
+		ctx.JSON(questionErrorStatus(err), response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.Success("question posted successfully", question))
+}
+
+// AnswerQuestion is open to admins and verified buyers, the service decides which
+func (c *QuestionController) AnswerQuestion(ctx *gin.Context) {
+	userID, exists := ctx.Get("UserID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, response.Failure("user not authorized", nil))
+		return
+	}
+	role, _ := ctx.Get("UserRole")
+	userRole, _ := role.(string)
+
+	var req dto.AnswerRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Failure("invalid request body", err.Error()))
+		return
+	}
+
+	question, err := c.QService.AnswerQuestion(userID.(string), userRole, ctx.Param("id"), ctx.Param("question_id"), req)
+	if err != nil {
+		ctx.JSON(questionErrorStatus(err), response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.Success("answer posted successfully", question))
+}
+
+// questionErrorStatus maps Q&A service errors to HTTP status codes
+func questionErrorStatus(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "invalid"):
+		return http.StatusBadRequest
+	case strings.Contains(msg, "verified buyers"):
+		return http.StatusForbidden
+	case strings.Contains(msg, "not found"):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,166 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/akhilnasimk/SS_backend/internal/dto"
+	"github.com/akhilnasimk/SS_backend/internal/services"
+	"github.com/akhilnasimk/SS_backend/utils/response"
+	"github.com/gin-gonic/gin"
+)
+
+type RaffleController struct {
+	RService services.RaffleService
+}
+
+func NewRaffleController(service services.RaffleService) RaffleController {
+	return RaffleController{
+		RService: service,
+	}
+}
+
+// GetOpenRaffles lists raffles that are taking entries or will open soon
+func (c *RaffleController) GetOpenRaffles(ctx *gin.Context) {
+	raffles, err := c.RService.GetOpenRaffles()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.Failure("failed to fetch raffles", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("raffles fetched successfully", raffles))
+}
+
+func (c *RaffleController) GetRaffle(ctx *gin.Context) {
+	raffle, err := c.RService.GetRaffle(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(raffleErrorStatus(err), response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("raffle fetched successfully", raffle))
+}
+
+func (c *RaffleController) CreateRaffle(ctx *gin.Context) {
+	var req dto.CreateRaffleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Failure("invalid request body", err.Error()))
+		return
+	}
+
+	raffle, err := c.RService.CreateRaffle(req)
+	if err != nil {
+		ctx.JSON(raffleErrorStatus(err), response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.Success("raffle created successfully", raffle))
+}
+
+func (c *RaffleController) EnterRaffle(ctx *gin.Context) {
+	userID, exists := ctx.Get("UserID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, response.Failure("user not authorized", nil))
+		return
+	}
+
+	var req dto.RaffleEntryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Failure("invalid request body", err.Error()))
+		return
+	}
+
+	entry, err := c.RService.EnterRaffle(userID.(string), ctx.Param("id"), req)
+	if err != nil {
+		ctx.JSON(raffleErrorStatus(err), response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.Success("raffle entry submitted", entry))
+}
+
+// GetMyEntry shows the caller's entry and, after the draw, whether it won
+func (c *RaffleController) GetMyEntry(ctx *gin.Context) {
+	userID, exists := ctx.Get("UserID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, response.Failure("user not authorized", nil))
+		return
+	}
+
+	entry, err := c.RService.GetMyEntry(userID.(string), ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(raffleErrorStatus(err), response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("raffle entry fetched successfully", entry))
+}
+
+func (c *RaffleController) DrawRaffle(ctx *gin.Context) {
+	adminID, exists := ctx.Get("UserID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, response.Failure("user not authorized", nil))
+		return
+	}
+
+	result, err := c.RService.DrawRaffle(adminID.(string), ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(raffleErrorStatus(err), response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("raffle drawn successfully", result))
+}
+
+func (c *RaffleController) GetRaffleEntries(ctx *gin.Context) {
+	entries, err := c.RService.GetRaffleEntries(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(raffleErrorStatus(err), response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("raffle entries fetched successfully", entries))
+}
+
+// PurchaseReservation lets a winner buy their reserved unit directly, skipping the cart
+func (c *RaffleController) PurchaseReservation(ctx *gin.Context) {
+	userID, exists := ctx.Get("UserID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, response.Failure("user not authorized", nil))
+		return
+	}
+
+	var req dto.CreateCartOrderDTO
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Failure("invalid request body", err.Error()))
+		return
+	}
+
+	order, err := c.RService.PurchaseReservation(userID.(string), ctx.Param("id"), req)
+	if err != nil {
+		ctx.JSON(raffleErrorStatus(err), response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.Success("order placed for raffle reservation", order))
+}
+
+// raffleErrorStatus maps raffle service errors to HTTP status codes
+func raffleErrorStatus(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "invalid"), strings.Contains(msg, "select a size"):
+		return http.StatusBadRequest
+	case strings.Contains(msg, "not found"):
+		return http.StatusNotFound
+	case strings.Contains(msg, "no winning reservation"):
+		return http.StatusForbidden
+	case strings.Contains(msg, "already"),
+		strings.Contains(msg, "not open"),
+		strings.Contains(msg, "still open"),
+		strings.Contains(msg, "expired"):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/akhilnasimk/SS_backend/internal/dto"
+	"github.com/akhilnasimk/SS_backend/internal/services"
+	"github.com/akhilnasimk/SS_backend/utils/response"
+	"github.com/gin-gonic/gin"
+)
+
+type RecentlyViewedController struct {
+	RVService services.RecentlyViewedService
+}
+
+func NewRecentlyViewedController(service services.RecentlyViewedService) RecentlyViewedController {
+	return RecentlyViewedController{
+		RVService: service,
+	}
+}
+
+// GetMine lists the logged-in customer's recently viewed products
+func (c *RecentlyViewedController) GetMine(ctx *gin.Context) {
+	userID, exists := ctx.Get("UserID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, response.Failure("user not authorized", nil))
+		return
+	}
+
+	views, err := c.RVService.GetForUser(userID.(string))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("recently viewed products fetched", views))
+}
+
+// GetRecentlyViewed serves both guests (guest_id cookie) and logged-in users
+func (c *RecentlyViewedController) GetRecentlyViewed(ctx *gin.Context) {
+	if _, exists := ctx.Get("UserID"); exists {
+		c.GetMine(ctx)
+		return
+	}
+
+	guestID := ctx.GetString("GuestID")
+	if guestID == "" {
+		ctx.JSON(http.StatusOK, response.Success("recently viewed products fetched", []dto.RecentlyViewedResponse{}))
+		return
+	}
+
+	views, err := c.RVService.GetForGuest(guestID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("recently viewed products fetched", views))
+}
@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/akhilnasimk/SS_backend/internal/services"
+	"github.com/akhilnasimk/SS_backend/utils/response"
+	"github.com/gin-gonic/gin"
+)
+
+type RecommendationController struct {
+	RService services.RecommendationService
+}
+
+func NewRecommendationController(service services.RecommendationService) RecommendationController {
+	return RecommendationController{
+		RService: service,
+	}
+}
+
+// GetFrequentlyBoughtTogether lists products often ordered with this one, ?limit= (default 8, max 20)
+func (c *RecommendationController) GetFrequentlyBoughtTogether(ctx *gin.Context) {
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "8"))
+
+	products, err := c.RService.GetFrequentlyBoughtTogether(ctx.Param("id"), limit)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case strings.Contains(err.Error(), "invalid"):
+			status = http.StatusBadRequest
+		case strings.Contains(err.Error(), "not found"):
+			status = http.StatusNotFound
+		}
+		ctx.JSON(status, response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("recommendations fetched successfully", products))
+}
+
+// RefreshRecommendations rebuilds the co-purchase pairs now instead of waiting for the next tick
+func (c *RecommendationController) RefreshRecommendations(ctx *gin.Context) {
+	pairs, err := c.RService.RefreshCoPurchases()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("recommendations refreshed", gin.H{"pairs": pairs}))
+}
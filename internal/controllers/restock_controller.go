@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/akhilnasimk/SS_backend/internal/services"
+	"github.com/akhilnasimk/SS_backend/utils/response"
+	"github.com/gin-gonic/gin"
+)
+
+type RestockController struct {
+	RService services.RestockService
+}
+
+func NewRestockController(service services.RestockService) RestockController {
+	return RestockController{
+		RService: service,
+	}
+}
+
+// Subscribe asks for an email when a sold-out product is back in stock
+func (c *RestockController) Subscribe(ctx *gin.Context) {
+	userID, exists := ctx.Get("UserID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, response.Failure("user not authorized", nil))
+		return
+	}
+
+	sub, err := c.RService.Subscribe(userID.(string), ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(restockErrorStatus(err), response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.Success("you will be emailed when this product is back in stock", sub))
+}
+
+func (c *RestockController) GetSubscription(ctx *gin.Context) {
+	userID, exists := ctx.Get("UserID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, response.Failure("user not authorized", nil))
+		return
+	}
+
+	sub, err := c.RService.GetSubscription(userID.(string), ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(restockErrorStatus(err), response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("restock alert fetched successfully", sub))
+}
+
+func (c *RestockController) Unsubscribe(ctx *gin.Context) {
+	userID, exists := ctx.Get("UserID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, response.Failure("user not authorized", nil))
+		return
+	}
+
+	if err := c.RService.Unsubscribe(userID.(string), ctx.Param("id")); err != nil {
+		ctx.JSON(restockErrorStatus(err), response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("restock alert removed", nil))
+}
+
+// restockErrorStatus maps restock alert service errors to HTTP status codes
+func restockErrorStatus(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "invalid"):
+		return http.StatusBadRequest
+	case strings.Contains(msg, "not found"):
+		return http.StatusNotFound
+	case strings.Contains(msg, "in stock"):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/akhilnasimk/SS_backend/internal/dto"
+	"github.com/akhilnasimk/SS_backend/internal/services"
+	"github.com/akhilnasimk/SS_backend/utils/response"
+	"github.com/gin-gonic/gin"
+)
+
+type ReviewController struct {
+	RService services.ReviewService
+}
+
+func NewReviewController(service services.ReviewService) ReviewController {
+	return ReviewController{
+		RService: service,
+	}
+}
+
+// GetProductReviews lists visible reviews, newest first
+func (c *ReviewController) GetProductReviews(ctx *gin.Context) {
+	c.listReviews(ctx, false)
+}
+
+// GetProductReviewsAdmin also lists hidden reviews for moderation
+func (c *ReviewController) GetProductReviewsAdmin(ctx *gin.Context) {
+	c.listReviews(ctx, true)
+}
+
+func (c *ReviewController) listReviews(ctx *gin.Context, includeHidden bool) {
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	reviews, err := c.RService.GetProductReviews(ctx.Param("id"), page, limit, includeHidden)
+	if err != nil {
+		ctx.JSON(reviewErrorStatus(err), response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("reviews fetched successfully", reviews))
+}
+
+func (c *ReviewController) CreateReview(ctx *gin.Context) {
+	userID, exists := ctx.Get("UserID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, response.Failure("user not authorized", nil))
+		return
+	}
+
+	req, ok := bindReviewRequest(ctx)
+	if !ok {
+		return
+	}
+
+	review, err := c.RService.CreateReview(userID.(string), ctx.Param("id"), req)
+	if err != nil {
+		ctx.JSON(reviewErrorStatus(err), response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.Success("review posted successfully", review))
+}
+
+func (c *ReviewController) UpdateReview(ctx *gin.Context) {
+	userID, exists := ctx.Get("UserID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, response.Failure("user not authorized", nil))
+		return
+	}
+
+	req, ok := bindReviewRequest(ctx)
+	if !ok {
+		return
+	}
+
+	review, err := c.RService.UpdateReview(userID.(string), ctx.Param("id"), req)
+	if err != nil {
+		ctx.JSON(reviewErrorStatus(err), response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("review updated successfully", review))
+}
+
+// SetReviewVisibility hides (or un-hides) a review, body: {"hidden": true, "reason": "..."}
+func (c *ReviewController) SetReviewVisibility(ctx *gin.Context) {
+	var req dto.HideReviewRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Failure("invalid request body", err.Error()))
+		return
+	}
+
+	review, err := c.RService.SetReviewHidden(ctx.Param("review_id"), *req.Hidden, req.Reason)
+	if err != nil {
+		ctx.JSON(reviewErrorStatus(err), response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("review visibility updated", review))
+}
+
+// bindReviewRequest reads the multipart review form, writing the 400 itself on failure
+func bindReviewRequest(ctx *gin.Context) (dto.ReviewRequest, bool) {
+	var req dto.ReviewRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Failure("invalid review data", err.Error()))
+		return req, false
+	}
+
+	if form, err := ctx.MultipartForm(); err == nil && form.File != nil {
+		req.Photos = form.File["photos"]
+	}
+
+	return req, true
+}
+
+// reviewErrorStatus maps review service errors to HTTP status codes
+func reviewErrorStatus(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "invalid"):
+		return http.StatusBadRequest
+	case strings.Contains(msg, "verified buyers"):
+		return http.StatusForbidden
+	case strings.Contains(msg, "already reviewed"):
+		return http.StatusConflict
+	case strings.Contains(msg, "not found"):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/akhilnasimk/SS_backend/internal/dto"
+	"github.com/akhilnasimk/SS_backend/internal/services"
+	"github.com/akhilnasimk/SS_backend/utils/response"
+	"github.com/gin-gonic/gin"
+)
+
+type StockController struct {
+	SService services.StockService
+	AService services.StockAlertService
+}
+
+func NewStockController(service services.StockService, alertService services.StockAlertService) StockController {
+	return StockController{
+		SService: service,
+		AService: alertService,
+	}
+}
+
+// GetStockHistory lists a product's stock movements, newest first
+func (c *StockController) GetStockHistory(ctx *gin.Context) {
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(ctx.DefaultQuery("limit", "20"))
+
+	history, err := c.SService.GetStockHistory(ctx.Param("id"), page, limit)
+	if err != nil {
+		ctx.JSON(stockErrorStatus(err), response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("stock history fetched successfully", history))
+}
+
+func (c *StockController) AdjustStock(ctx *gin.Context) {
+	adminID, exists := ctx.Get("UserID")
+	if !exists {
+		ctx.JSON(http.StatusUnauthorized, response.Failure("user not authorized", nil))
+		return
+	}
+
+	var req dto.StockAdjustmentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, response.Failure("invalid request body", err.Error()))
+		return
+	}
+
+	movement, err := c.SService.AdjustStock(adminID.(string), ctx.Param("id"), req)
+	if err != nil {
+		ctx.JSON(stockErrorStatus(err), response.Failure(err.Error(), nil))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.Success("stock adjusted successfully", movement))
+}
+
+// ReconcileStock reports products whose stock_count differs from their ledger (?all=true lists every product)
+func (c *StockController) ReconcileStock(ctx *gin.Context) {
+	all := ctx.Query("all") == "true"
+
+	report, err := c.SService.ReconcileStock(all)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.Failure("failed to reconcile stock", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("stock reconciled", report))
+}
+
+// GetLowStock lists products at or below their low-stock threshold, or with a size sold out
+func (c *StockController) GetLowStock(ctx *gin.Context) {
+	products, err := c.AService.GetLowStock()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, response.Failure("failed to fetch low stock products", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.Success("low stock products fetched successfully", products))
+}
+
+// stockErrorStatus maps stock ledger service errors to HTTP status codes
+func stockErrorStatus(err error) int {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "invalid"), strings.Contains(msg, "select a size"):
+		return http.StatusBadRequest
+	case strings.Contains(msg, "not found"):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
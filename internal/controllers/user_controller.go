@@ -113,6 +113,26 @@ func (c *UserController) GetAllUsers(ctx *gin.Context) {
 		offset = 0
 	}
 
+	// Keyset mode: ?cursor= (empty for the first page) then follow next_cursor
+	if cursor, ok := ctx.GetQuery("cursor"); ok {
+		users, next, err := c.UserService.GetUsersByCursor(limit, cursor)
+		if err != nil {
+			ctx.JSON(400, gin.H{
+				"success": false,
+				"message": "Failed to fetch users",
+				"error":   err.Error(),
+			})
+			return
+		}
+
+		ctx.JSON(200, gin.H{
+			"success":     true,
+			"data":        users,
+			"next_cursor": next,
+		})
+		return
+	}
+
 	// Call service to get users
 	users, err := c.UserService.GetAllUserData(limit, offset)
 	if err != nil {
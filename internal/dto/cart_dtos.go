@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"time"
 
+	constent "github.com/akhilnasimk/SS_backend/internal/const"
 	"github.com/akhilnasimk/SS_backend/internal/models"
 	"github.com/google/uuid"
 )
@@ -17,6 +18,12 @@ type CartItemResponse struct {
 	Total       float64   `json:"total"`
 	Quantity    int       `json:"quantity"`
 	Catogory    uuid.UUID `json:"catogory"`
+
+	// Selected size (empty for products without variants)
+	VariantID *uuid.UUID `json:"variant_id,omitempty"`
+	Size      string     `json:"size,omitempty"`
+	Colorway  string     `json:"colorway,omitempty"`
+	SKU       string     `json:"sku,omitempty"`
 }
 
 type CartResponse struct {
@@ -41,7 +48,7 @@ func MapCartToCartResponse(cart models.Cart) CartResponse {
 		var firstPhoto string
 		for _, img := range ci.Product.Images {
 			if !img.DeletedAt.Valid {
-				firstPhoto = img.URL
+				firstPhoto = img.SizedURL(constent.ImageSizeThumbnail)
 				fmt.Println("the current image:", img)
 				break
 			}
@@ -49,11 +56,19 @@ func MapCartToCartResponse(cart models.Cart) CartResponse {
 
 		// fmt.Println("length of the image array is ", (ci.Product.Images))
 
+		// variant was removed or disabled after it was added
+		if ci.VariantID != nil && (ci.Variant == nil || !ci.Variant.IsActive) {
+			continue
+		}
+
 		price := float64(ci.Product.Price)
+		if ci.Variant != nil {
+			price = float64(ci.Variant.EffectivePrice(ci.Product.Price))
+		}
 		total := price * float64(ci.Quantity)
 		grandTotal += total
 
-		items = append(items, CartItemResponse{
+		item := CartItemResponse{
 			CartItemID:  ci.ID,
 			ProductID:   ci.Product.ID,
 			ProductName: ci.Product.Name,
@@ -62,7 +77,10 @@ func MapCartToCartResponse(cart models.Cart) CartResponse {
 			Total:       total,
 			Photo:       firstPhoto,
 			Catogory:    ci.Product.CategoryID,
-		})
+		}
+		ApplyCartVariant(&item, ci.Variant)
+
+		items = append(items, item)
 	}
 
 	return CartResponse{
@@ -74,6 +92,17 @@ func MapCartToCartResponse(cart models.Cart) CartResponse {
 	}
 }
 
+// ApplyCartVariant fills the size fields of a cart line
+func ApplyCartVariant(item *CartItemResponse, variant *models.ProductVariant) {
+	if variant == nil {
+		return
+	}
+	item.VariantID = &variant.ID
+	item.Size = variant.Size
+	item.Colorway = variant.Colorway
+	item.SKU = variant.SKU
+}
+
 type AddCartRequest struct {
 	ProductID uuid.UUID `json:"product_id" binding:"required"`
 	Quantity  int       `json:"quantity" binding:"required,min=1"`
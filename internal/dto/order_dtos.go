@@ -1,9 +1,16 @@
 package dto
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
 type CreateSingleOrderDTO struct {
 	Quantity        int    `json:"quantity" binding:"required,min=1"`
 	ShippingAddress string `json:"shipping_address" binding:"required"`
 	PaymentMethod   string `json:"payment_method" binding:"required"`
+	VariantID       string `json:"variant_id"` // required when the product has sizes
 }
 
 type CreateCartOrderDTO struct {
@@ -14,3 +21,15 @@ type CreateCartOrderDTO struct {
 type UpdateOrderStatusDTO struct {
 	Status string `json:"status" binding:"required"`
 }
+
+// CheckoutHoldResponse is what starting checkout reserved, valid until ExpiresAt
+type CheckoutHoldResponse struct {
+	ExpiresAt time.Time          `json:"expires_at"`
+	Items     []CheckoutHoldItem `json:"items"`
+}
+
+type CheckoutHoldItem struct {
+	ProductID uuid.UUID  `json:"product_id"`
+	VariantID *uuid.UUID `json:"variant_id,omitempty"`
+	Quantity  int        `json:"quantity"`
+}
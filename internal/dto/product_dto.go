@@ -4,14 +4,47 @@ import (
 	"mime/multipart"
 	"time"
 
+	constent "github.com/akhilnasimk/SS_backend/internal/const"
 	"github.com/akhilnasimk/SS_backend/internal/models"
 	"github.com/google/uuid"
 )
 
 type ProductImageResponse struct {
-	ID      uuid.UUID `json:"id"`
-	URL     string    `json:"url"`
-	AltText string    `json:"alt_text"`
+	ID           uuid.UUID `json:"id"`
+	URL          string    `json:"url"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+	CardURL      string    `json:"card_url"`
+	ZoomURL      string    `json:"zoom_url"`
+	AltText      string    `json:"alt_text"`
+	Priority     int       `json:"priority"`
+	IsPrimary    bool      `json:"is_primary"`
+}
+
+// ToProductImageResponses maps images that are already in display order (primary first)
+func ToProductImageResponses(imgs []models.ProductImage) []ProductImageResponse {
+	images := make([]ProductImageResponse, len(imgs))
+	for i, img := range imgs {
+		images[i] = ProductImageResponse{
+			ID:           img.ID,
+			URL:          img.URL,
+			ThumbnailURL: img.SizedURL(constent.ImageSizeThumbnail),
+			CardURL:      img.SizedURL(constent.ImageSizeCard),
+			ZoomURL:      img.SizedURL(constent.ImageSizeZoom),
+			AltText:      img.AltText,
+			Priority:     img.Priority,
+			IsPrimary:    i == 0,
+		}
+	}
+	return images
+}
+
+// ReorderImagesRequest lists every image of the product in the new display order
+type ReorderImagesRequest struct {
+	ImageIDs []string `json:"image_ids" binding:"required,min=1"`
+}
+
+type UpdateImageAltTextRequest struct {
+	AltText string `json:"alt_text" binding:"required,max=255"`
 }
 
 type ProductResponse struct {
@@ -22,23 +55,39 @@ type ProductResponse struct {
 	StockCount  int       `json:"stock_count"`
 	IsActive    bool      `json:"is_active"`
 
+	Slug            string `json:"slug"`
+	MetaTitle       string `json:"meta_title"`
+	MetaDescription string `json:"meta_description"`
+
+	// Timed drop window, drop_status is upcoming / live / ended
+	ReleaseAt  *time.Time `json:"release_at"`
+	EndsAt     *time.Time `json:"ends_at"`
+	DropStatus string     `json:"drop_status"`
+
+	MaxPerCustomer    *int `json:"max_per_customer"`
+	LowStockThreshold int  `json:"low_stock_threshold"`
+
+	AverageRating float64 `json:"average_rating"`
+	ReviewCount   int     `json:"review_count"`
+
 	CategoryID uuid.UUID `json:"category_id"`
 	Category   string    `json:"category_name"` // optional, if you preload category
 
 	Images []ProductImageResponse `json:"images"`
 
+	// Sizes for the storefront size picker
+	Variants []ProductVariantResponse `json:"variants"`
+
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
 }
 
 func ToProductResponse(p models.Product) ProductResponse {
-	images := make([]ProductImageResponse, len(p.Images))
-	for i, img := range p.Images {
-		images[i] = ProductImageResponse{
-			ID:      img.ID,
-			URL:     img.URL,
-			AltText: img.AltText,
-		}
+	images := ToProductImageResponses(p.Images)
+
+	variants := make([]ProductVariantResponse, len(p.Variants))
+	for i, v := range p.Variants {
+		variants[i] = ToProductVariantResponse(v, p.Price)
 	}
 
 	return ProductResponse{
@@ -48,21 +97,179 @@ func ToProductResponse(p models.Product) ProductResponse {
 		Price:       p.Price,
 		StockCount:  p.StockCount,
 		IsActive:    p.IsActive,
-		CategoryID:  p.CategoryID,
-		Category:    p.Category.Name,
-		Images:      images,
-		CreatedAt:   p.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   p.UpdatedAt.Format(time.RFC3339),
+
+		Slug:            p.Slug,
+		MetaTitle:       p.MetaTitle,
+		MetaDescription: p.MetaDescription,
+
+		ReleaseAt:  p.ReleaseAt,
+		EndsAt:     p.EndsAt,
+		DropStatus: string(p.DropStatus(time.Now())),
+
+		MaxPerCustomer:    p.MaxPerCustomer,
+		LowStockThreshold: p.LowStockThreshold,
+
+		AverageRating: p.AverageRating,
+		ReviewCount:   p.ReviewCount,
+
+		CategoryID: p.CategoryID,
+		Category:   p.Category.Name,
+		Images:     images,
+		Variants:   variants,
+		CreatedAt:  p.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  p.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+type ProductVariantResponse struct {
+	ID            uuid.UUID `json:"id"`
+	Size          string    `json:"size"`
+	Colorway      string    `json:"colorway"`
+	SKU           string    `json:"sku"`
+	StockCount    int       `json:"stock_count"`
+	InStock       bool      `json:"in_stock"`
+	Price         int64     `json:"price"` // effective price (override or product price)
+	PriceOverride *int64    `json:"price_override,omitempty"`
+	IsActive      bool      `json:"is_active"`
+
+	MaxPerCustomer *int `json:"max_per_customer,omitempty"`
+}
+
+func ToProductVariantResponse(v models.ProductVariant, productPrice int64) ProductVariantResponse {
+	return ProductVariantResponse{
+		ID:            v.ID,
+		Size:          v.Size,
+		Colorway:      v.Colorway,
+		SKU:           v.SKU,
+		StockCount:    v.StockCount,
+		InStock:       v.StockCount > 0,
+		Price:         v.EffectivePrice(productPrice),
+		PriceOverride: v.PriceOverride,
+		IsActive:      v.IsActive,
+
+		MaxPerCustomer: v.MaxPerCustomer,
 	}
 }
 
+// VariantRequest is used by the admin to create or update a variant
+type VariantRequest struct {
+	Size          string `json:"size" binding:"required"`
+	Colorway      string `json:"colorway"`
+	SKU           string `json:"sku" binding:"required"`
+	StockCount    int    `json:"stock_count" binding:"gte=0"`
+	PriceOverride *int64 `json:"price_override" binding:"omitempty,gt=0"`
+	IsActive      *bool  `json:"is_active"`
+
+	MaxPerCustomer *int `json:"max_per_customer" binding:"omitempty,gt=0"` // empty = no per-size cap
+}
+
+// FacetBucket is one entry of a listing facet with the number of matching products
+type FacetBucket struct {
+	Key   string `json:"key"`
+	Label string `json:"label"`
+	Min   *int64 `json:"min,omitempty"` // price buckets only
+	Max   *int64 `json:"max,omitempty"` // price buckets only (absent = no upper bound)
+	Count int64  `json:"count"`
+}
+
+// ProductFacets is returned alongside a product listing for the filter sidebar
+type ProductFacets struct {
+	Categories   []FacetBucket `json:"categories"`
+	PriceBuckets []FacetBucket `json:"price_buckets"`
+	Availability []FacetBucket `json:"availability"`
+}
+
 type CategoryResponse struct {
-	ID        uuid.UUID `json:"id"`
-	Name      string    `json:"Name"`
-	CreatedAt time.Time `json:"created_at"`
+	ID           uuid.UUID          `json:"id"`
+	Name         string             `json:"Name"`
+	Slug         string             `json:"slug"`
+	ParentID     *uuid.UUID         `json:"parent_id"`
+	DisplayOrder int                `json:"display_order"`
+	CreatedAt    time.Time          `json:"created_at"`
+	Children     []CategoryResponse `json:"children,omitempty"`
+}
+
+// CategoryRequest creates or replaces a category; empty slug is generated from the name
+type CategoryRequest struct {
+	Name         string `json:"name" binding:"required"`
+	Slug         string `json:"slug"`
+	ParentID     string `json:"parent_id"` // empty = top level
+	DisplayOrder int    `json:"display_order"`
+}
+
+// TrashedProductResponse is a soft-deleted product as shown in the admin trash
+type TrashedProductResponse struct {
+	ProductResponse
+	DeletedAt  time.Time `json:"deleted_at"`
+	PurgeAfter time.Time `json:"purge_after"`
+}
+
+func ToTrashedProductResponse(p models.Product, retention time.Duration) TrashedProductResponse {
+	return TrashedProductResponse{
+		ProductResponse: ToProductResponse(p),
+		DeletedAt:       p.DeletedAt.Time,
+		PurgeAfter:      p.DeletedAt.Time.Add(retention),
+	}
+}
+
+func ToCategoryResponse(c models.Category) CategoryResponse {
+	return CategoryResponse{
+		ID:           c.ID,
+		Name:         c.Name,
+		Slug:         c.Slug,
+		ParentID:     c.ParentID,
+		DisplayOrder: c.DisplayOrder,
+		CreatedAt:    c.CreatedAt,
+	}
+}
+
+
+
+// ProductSEO holds the admin-editable SEO fields. On create an empty slug is generated from the
+// name. On update every empty field keeps its current value; the flags regenerate the slug from
+// the name or remove a meta field.
+type ProductSEO struct {
+	Slug            string `form:"slug" binding:"max=255"`
+	MetaTitle       string `form:"meta_title" binding:"max=150"`
+	MetaDescription string `form:"meta_description" binding:"max=320"`
+
+	RegenerateSlug       bool `form:"regenerate_slug"`
+	ClearMetaTitle       bool `form:"clear_meta_title"`
+	ClearMetaDescription bool `form:"clear_meta_description"`
+}
+
+// ProductSchedule is the optional drop window, RFC 3339 timestamps. On create empty means no limit;
+// on update empty keeps the current value and the clear flags remove it.
+type ProductSchedule struct {
+	ReleaseAt string `form:"release_at"`
+	EndsAt    string `form:"ends_at"`
+
+	ClearReleaseAt bool `form:"clear_release_at"`
+	ClearEndsAt    bool `form:"clear_ends_at"`
+}
+
+// ProductLimits is the optional per-customer purchase cap; empty keeps the current cap (none for
+// new products) and clear_max_per_customer removes it. An empty low_stock_threshold keeps the
+// current one (LOW_STOCK_THRESHOLD for new products).
+type ProductLimits struct {
+	MaxPerCustomer    string `form:"max_per_customer"`
+	LowStockThreshold string `form:"low_stock_threshold"`
+
+	ClearMaxPerCustomer bool `form:"clear_max_per_customer"`
 }
 
+// UpcomingDropResponse is a product that is not released yet, with its countdown
+type UpcomingDropResponse struct {
+	Product         ProductResponse `json:"product"`
+	ReleaseAt       time.Time       `json:"release_at"`
+	StartsInSeconds int64           `json:"starts_in_seconds"`
+}
 
+// UpcomingDropsResponse carries the server time so clients can sync their countdowns
+type UpcomingDropsResponse struct {
+	ServerTime time.Time              `json:"server_time"`
+	Drops      []UpcomingDropResponse `json:"drops"`
+}
 
 type UpdateProductRequest struct {
 	Name        string `form:"name" binding:"required"`
@@ -71,6 +278,10 @@ type UpdateProductRequest struct {
 	StockCount  int    `form:"stock_count" binding:"required,gte=0"`
 	CategoryID  string `form:"category_id" binding:"required"`
 
+	ProductSEO
+	ProductSchedule
+	ProductLimits
+
 	// URLs that admin wants to KEEP
 	// This won't auto-bind from form, we'll set it manually
 	KeepImages []string
@@ -78,4 +289,19 @@ type UpdateProductRequest struct {
 	// New files the admin uploads
 	// This won't auto-bind from form, we'll set it manually
 	NewImages []*multipart.FileHeader
-}
\ No newline at end of file
+}
+
+// ImportRowError points at a CSV line (header = line 1) that failed validation
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+// ProductImportReport is the outcome of a CSV import (dry run or applied)
+type ProductImportReport struct {
+	DryRun    bool             `json:"dry_run"`
+	TotalRows int              `json:"total_rows"`
+	Created   int              `json:"created"`
+	Updated   int              `json:"updated"`
+	Errors    []ImportRowError `json:"errors"`
+}
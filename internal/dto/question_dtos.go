@@ -0,0 +1,78 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/google/uuid"
+)
+
+type QuestionRequest struct {
+	Body string `json:"body" binding:"required,min=3,max=2000"`
+}
+
+type AnswerRequest struct {
+	Body string `json:"body" binding:"required,min=1,max=5000"`
+}
+
+type QuestionResponse struct {
+	ID        uuid.UUID        `json:"id"`
+	ProductID uuid.UUID        `json:"product_id"`
+	UserName  string           `json:"user_name"`
+	Body      string           `json:"body"`
+	Answers   []AnswerResponse `json:"answers"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+type AnswerResponse struct {
+	ID              uuid.UUID `json:"id"`
+	UserName        string    `json:"user_name"`
+	Body            string    `json:"body"`
+	IsAdmin         bool      `json:"is_admin"`
+	IsVerifiedBuyer bool      `json:"is_verified_buyer"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type QuestionListResponse struct {
+	Questions []QuestionResponse `json:"questions"`
+	Total     int64              `json:"total"`
+	Page      int                `json:"page"`
+	Limit     int                `json:"limit"`
+}
+
+func ToQuestionResponse(q models.ProductQuestion) QuestionResponse {
+	answers := make([]AnswerResponse, 0, len(q.Answers))
+	for _, a := range q.Answers {
+		answers = append(answers, ToAnswerResponse(a))
+	}
+
+	userName := ""
+	if q.User != nil {
+		userName = q.User.UserName
+	}
+
+	return QuestionResponse{
+		ID:        q.ID,
+		ProductID: q.ProductID,
+		UserName:  userName,
+		Body:      q.Body,
+		Answers:   answers,
+		CreatedAt: q.CreatedAt,
+	}
+}
+
+func ToAnswerResponse(a models.ProductAnswer) AnswerResponse {
+	userName := ""
+	if a.User != nil {
+		userName = a.User.UserName
+	}
+
+	return AnswerResponse{
+		ID:              a.ID,
+		UserName:        userName,
+		Body:            a.Body,
+		IsAdmin:         a.IsAdmin,
+		IsVerifiedBuyer: a.IsVerifiedBuyer,
+		CreatedAt:       a.CreatedAt,
+	}
+}
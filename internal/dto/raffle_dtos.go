@@ -0,0 +1,121 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/enums"
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/google/uuid"
+)
+
+type CreateRaffleRequest struct {
+	ProductID        string `json:"product_id" binding:"required"`
+	OpensAt          string `json:"opens_at" binding:"required"`  // RFC3339
+	ClosesAt         string `json:"closes_at" binding:"required"` // RFC3339
+	ReservationHours int    `json:"reservation_hours" binding:"omitempty,min=1,max=168"`
+}
+
+type RaffleEntryRequest struct {
+	VariantID string `json:"variant_id"` // required when the product has sizes
+}
+
+type RaffleResponse struct {
+	ID               uuid.UUID          `json:"id"`
+	Product          ProductResponse    `json:"product"`
+	OpensAt          time.Time          `json:"opens_at"`
+	ClosesAt         time.Time          `json:"closes_at"`
+	Status           enums.RaffleStatus `json:"status"`
+	ReservationHours int                `json:"reservation_hours"`
+	EntryCount       int64              `json:"entry_count"`
+	SeedHash         string             `json:"seed_hash"`      // sha256 of the seed, published from creation
+	Seed             string             `json:"seed,omitempty"` // revealed by the draw
+	DrawnAt          *time.Time         `json:"drawn_at,omitempty"`
+	WinnerCount      int                `json:"winner_count"`
+}
+
+type RaffleEntryResponse struct {
+	ID                   uuid.UUID               `json:"id"`
+	RaffleID             uuid.UUID               `json:"raffle_id"`
+	VariantID            *uuid.UUID              `json:"variant_id"`
+	Size                 string                  `json:"size,omitempty"`
+	Status               enums.RaffleEntryStatus `json:"status"`
+	ReservationExpiresAt *time.Time              `json:"reservation_expires_at,omitempty"`
+	OrderID              *uuid.UUID              `json:"order_id,omitempty"`
+	CreatedAt            time.Time               `json:"created_at"`
+}
+
+// RaffleAuditEntry is one line of the draw record: ticket = sha256(seed:entry id)
+type RaffleAuditEntry struct {
+	EntryID      uuid.UUID               `json:"entry_id"`
+	UserID       uuid.UUID               `json:"user_id"`
+	UserName     string                  `json:"user_name"`
+	VariantID    *uuid.UUID              `json:"variant_id"`
+	Size         string                  `json:"size,omitempty"`
+	Ticket       string                  `json:"ticket,omitempty"`
+	DrawPosition *int                    `json:"draw_position,omitempty"`
+	Status       enums.RaffleEntryStatus `json:"status"`
+	EnteredAt    time.Time               `json:"entered_at"`
+}
+
+type RaffleDrawResponse struct {
+	Raffle  RaffleResponse     `json:"raffle"`
+	DrawnBy *uuid.UUID         `json:"drawn_by"`
+	Entries []RaffleAuditEntry `json:"entries"`
+}
+
+func ToRaffleResponse(r models.Raffle, entryCount int64) RaffleResponse {
+	resp := RaffleResponse{
+		ID:               r.ID,
+		OpensAt:          r.OpensAt,
+		ClosesAt:         r.ClosesAt,
+		Status:           r.Status,
+		ReservationHours: r.ReservationHours,
+		EntryCount:       entryCount,
+		SeedHash:         r.SeedHash,
+		DrawnAt:          r.DrawnAt,
+		WinnerCount:      r.WinnerCount,
+	}
+	// the seed stays secret until the draw
+	if r.DrawnAt != nil {
+		resp.Seed = r.Seed
+	}
+	if r.Product != nil {
+		resp.Product = ToProductResponse(*r.Product)
+	}
+	return resp
+}
+
+func ToRaffleEntryResponse(e models.RaffleEntry) RaffleEntryResponse {
+	resp := RaffleEntryResponse{
+		ID:                   e.ID,
+		RaffleID:             e.RaffleID,
+		VariantID:            e.VariantID,
+		Status:               e.Status,
+		ReservationExpiresAt: e.ReservationExpiresAt,
+		OrderID:              e.OrderID,
+		CreatedAt:            e.CreatedAt,
+	}
+	if e.Variant != nil {
+		resp.Size = e.Variant.Size
+	}
+	return resp
+}
+
+func ToRaffleAuditEntry(e models.RaffleEntry) RaffleAuditEntry {
+	entry := RaffleAuditEntry{
+		EntryID:      e.ID,
+		UserID:       e.UserID,
+		VariantID:    e.VariantID,
+		Ticket:       e.Ticket,
+		DrawPosition: e.DrawPosition,
+		Status:       e.Status,
+		EnteredAt:    e.CreatedAt,
+	}
+	if e.User != nil {
+		entry.UserName = e.User.UserName
+	}
+	if e.Variant != nil {
+		entry.Size = e.Variant.Size
+	}
+	return entry
+}
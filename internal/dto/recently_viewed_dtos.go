@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/models"
+)
+
+type RecentlyViewedResponse struct {
+	Product  ProductResponse `json:"product"`
+	ViewedAt time.Time       `json:"viewed_at"`
+}
+
+func ToRecentlyViewedResponses(views []models.RecentlyViewed) []RecentlyViewedResponse {
+	resp := make([]RecentlyViewedResponse, 0, len(views))
+	for _, v := range views {
+		if v.Product == nil {
+			continue
+		}
+		resp = append(resp, RecentlyViewedResponse{
+			Product:  ToProductResponse(*v.Product),
+			ViewedAt: v.ViewedAt,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"mime/multipart"
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// ReviewRequest is sent as multipart form (photos go in the "photos" field)
+type ReviewRequest struct {
+	Rating int    `form:"rating" binding:"required,min=1,max=5"`
+	Title  string `form:"title" binding:"max=150"`
+	Body   string `form:"body" binding:"max=5000"`
+
+	// set manually from the multipart form
+	Photos []*multipart.FileHeader `form:"-"`
+}
+
+type HideReviewRequest struct {
+	Hidden *bool  `json:"hidden" binding:"required"`
+	Reason string `json:"reason" binding:"max=255"`
+}
+
+type ReviewResponse struct {
+	ID        uuid.UUID             `json:"id"`
+	ProductID uuid.UUID             `json:"product_id"`
+	UserName  string                `json:"user_name"`
+	Rating    int                   `json:"rating"`
+	Title     string                `json:"title"`
+	Body      string                `json:"body"`
+	Photos    []ReviewPhotoResponse `json:"photos"`
+	IsHidden  bool                  `json:"is_hidden,omitempty"`
+	CreatedAt time.Time             `json:"created_at"`
+	UpdatedAt time.Time             `json:"updated_at"`
+}
+
+type ReviewPhotoResponse struct {
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
+
+type ReviewListResponse struct {
+	Reviews       []ReviewResponse `json:"reviews"`
+	Total         int64            `json:"total"`
+	Page          int              `json:"page"`
+	Limit         int              `json:"limit"`
+	AverageRating float64          `json:"average_rating"`
+	ReviewCount   int              `json:"review_count"`
+}
+
+func ToReviewResponse(r models.Review) ReviewResponse {
+	photos := make([]ReviewPhotoResponse, 0, len(r.Photos))
+	for _, p := range r.Photos {
+		thumb := p.ThumbnailURL
+		if thumb == "" {
+			thumb = p.URL
+		}
+		photos = append(photos, ReviewPhotoResponse{URL: p.URL, ThumbnailURL: thumb})
+	}
+
+	userName := ""
+	if r.User != nil {
+		userName = r.User.UserName
+	}
+
+	return ReviewResponse{
+		ID:        r.ID,
+		ProductID: r.ProductID,
+		UserName:  userName,
+		Rating:    r.Rating,
+		Title:     r.Title,
+		Body:      r.Body,
+		Photos:    photos,
+		IsHidden:  r.IsHidden,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
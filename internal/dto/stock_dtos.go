@@ -0,0 +1,117 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/enums"
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// StockAdjustmentRequest is a manual stock change by an admin: a correction or a customer return
+type StockAdjustmentRequest struct {
+	Delta     int    `json:"delta" binding:"required"`  // non-zero, negative removes stock
+	Reason    string `json:"reason" binding:"required"` // admin_adjustment or return
+	VariantID string `json:"variant_id"`                // required when the product has sizes
+	Note      string `json:"note" binding:"max=255"`
+}
+
+type StockMovementResponse struct {
+	ID            uuid.UUID                 `json:"id"`
+	ProductID     uuid.UUID                 `json:"product_id"`
+	VariantID     *uuid.UUID                `json:"variant_id,omitempty"`
+	Delta         int                       `json:"delta"`
+	Reason        enums.StockMovementReason `json:"reason"`
+	ReferenceType string                    `json:"reference_type,omitempty"`
+	ReferenceID   *uuid.UUID                `json:"reference_id,omitempty"`
+	ActorID       *uuid.UUID                `json:"actor_id,omitempty"`
+	Note          string                    `json:"note,omitempty"`
+	CreatedAt     time.Time                 `json:"created_at"`
+}
+
+type StockHistoryResponse struct {
+	ProductID  uuid.UUID               `json:"product_id"`
+	StockCount int                     `json:"stock_count"`
+	Movements  []StockMovementResponse `json:"movements"`
+	Total      int64                   `json:"total"`
+	Page       int                     `json:"page"`
+	Limit      int                     `json:"limit"`
+}
+
+// StockReconciliationRow is one product whose ledger total was compared with its stock_count
+type StockReconciliationRow struct {
+	ProductID   uuid.UUID `json:"product_id"`
+	ProductName string    `json:"product_name"`
+	StockCount  int       `json:"stock_count"`
+	LedgerStock int       `json:"ledger_stock"`
+	Difference  int       `json:"difference"` // stock_count - ledger_stock
+	Movements   int64     `json:"movements"`
+}
+
+type StockReconciliationResponse struct {
+	Checked    int                      `json:"checked"`
+	Mismatched int                      `json:"mismatched"`
+	Products   []StockReconciliationRow `json:"products"`
+}
+
+func ToStockMovementResponse(m models.StockMovement) StockMovementResponse {
+	return StockMovementResponse{
+		ID:            m.ID,
+		ProductID:     m.ProductID,
+		VariantID:     m.VariantID,
+		Delta:         m.Delta,
+		Reason:        m.Reason,
+		ReferenceType: m.ReferenceType,
+		ReferenceID:   m.ReferenceID,
+		ActorID:       m.ActorID,
+		Note:          m.Note,
+		CreatedAt:     m.CreatedAt,
+	}
+}
+
+// LowStockProduct is a product at or below its low-stock threshold, or with a size sold out
+type LowStockProduct struct {
+	ProductID     uuid.UUID  `json:"product_id"`
+	Name          string     `json:"name"`
+	Slug          string     `json:"slug"`
+	StockCount    int        `json:"stock_count"`
+	Threshold     int        `json:"low_stock_threshold"`
+	SoldOutSizes  []string   `json:"sold_out_sizes,omitempty"`
+	LastAlertedAt *time.Time `json:"last_alerted_at,omitempty"`
+}
+
+func ToLowStockProduct(p models.Product) LowStockProduct {
+	resp := LowStockProduct{
+		ProductID:     p.ID,
+		Name:          p.Name,
+		Slug:          p.Slug,
+		StockCount:    p.StockCount,
+		Threshold:     p.LowStockThreshold,
+		LastAlertedAt: p.LowStockAlertedAt,
+	}
+	for _, v := range p.Variants {
+		if v.StockCount <= 0 {
+			resp.SoldOutSizes = append(resp.SoldOutSizes, v.Size)
+		}
+	}
+	return resp
+}
+
+// RestockSubscriptionResponse is the caller's back-in-stock alert for one product
+type RestockSubscriptionResponse struct {
+	ProductID  uuid.UUID           `json:"product_id"`
+	Subscribed bool                `json:"subscribed"` // true while waiting for the restock email
+	Status     enums.RestockStatus `json:"status,omitempty"`
+	NotifiedAt *time.Time          `json:"notified_at,omitempty"`
+	CreatedAt  *time.Time          `json:"created_at,omitempty"`
+}
+
+func ToRestockSubscriptionResponse(s models.RestockSubscription) RestockSubscriptionResponse {
+	return RestockSubscriptionResponse{
+		ProductID:  s.ProductID,
+		Subscribed: s.Status == enums.RestockPending,
+		Status:     s.Status,
+		NotifiedAt: s.NotifiedAt,
+		CreatedAt:  &s.CreatedAt,
+	}
+}
@@ -15,6 +15,8 @@ type UserProfileResponse struct {
 	Address   *string   `json:"address,omitempty"`
 	IsBlocked bool      `json:"is_blocked"`
 	UserRole  *string   `json:"user_role"`
+
+	PriceDropAlerts bool `json:"price_drop_alerts"`
 }
 
 type UpdateProfileRequest struct {
@@ -23,6 +25,9 @@ type UpdateProfileRequest struct {
 	Image    *string `json:"image"`
 	Phone    *string `json:"phone"`
 	Address  *string `json:"address"`
+
+	// false stops wishlist price-drop emails
+	PriceDropAlerts *bool `json:"price_drop_alerts"`
 }
 
 type AdminUserResponse struct {
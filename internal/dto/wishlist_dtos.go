@@ -3,14 +3,20 @@ package dto
 import (
 	"time"
 
+	constent "github.com/akhilnasimk/SS_backend/internal/const"
 	"github.com/akhilnasimk/SS_backend/internal/models"
 	"github.com/google/uuid"
 )
 
 type WishlistItemDTO struct {
-	ID        uuid.UUID     `json:"id"`
-	Product   ProductMinDTO `json:"product"`
-	CreatedAt time.Time     `json:"created_at"`
+	ID      uuid.UUID     `json:"id"`
+	Product ProductMinDTO `json:"product"`
+
+	// price when wishlisted and the change since (current - then, negative = cheaper now)
+	PriceAtAdd  int64 `json:"price_at_add"`
+	PriceChange int64 `json:"price_change"`
+
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type ProductMinDTO struct {
@@ -40,7 +46,7 @@ func ToWishlistDTO(items []models.Wishlist) []WishlistItemDTO {
 			firstImg = []ProductImageDTO{
 				{
 					ID:       img.ID,
-					URL:      img.URL,
+					URL:      img.SizedURL(constent.ImageSizeCard),
 					AltText:  img.AltText,
 					Priority: img.Priority,
 				},
@@ -48,8 +54,10 @@ func ToWishlistDTO(items []models.Wishlist) []WishlistItemDTO {
 		}
 
 		result = append(result, WishlistItemDTO{
-			ID:        w.ID,
-			CreatedAt: w.CreatedAt,
+			ID:          w.ID,
+			CreatedAt:   w.CreatedAt,
+			PriceAtAdd:  w.PriceAtAdd,
+			PriceChange: w.Product.Price - w.PriceAtAdd,
 			Product: ProductMinDTO{
 				ID:          w.Product.ID,
 				Name:        w.Product.Name,
@@ -0,0 +1,57 @@
+package enums
+
+// ProductSort is a catalog ordering accepted by GET /products?sort=
+type ProductSort string
+
+const (
+	SortNewest      ProductSort = "newest"
+	SortPriceAsc    ProductSort = "price_asc"
+	SortPriceDesc   ProductSort = "price_desc"
+	SortName        ProductSort = "name"
+	SortBestSelling ProductSort = "best_selling"
+	SortRelevance   ProductSort = "relevance"
+	SortTopRated    ProductSort = "top_rated"
+)
+
+func (s ProductSort) IsValid() bool {
+	switch s {
+	case SortNewest, SortPriceAsc, SortPriceDesc, SortName, SortBestSelling, SortRelevance, SortTopRated:
+		return true
+	}
+	return false
+}
+
+// DropStatus is where a product sits in its release window (release_at / ends_at)
+type DropStatus string
+
+const (
+	DropUpcoming DropStatus = "upcoming"
+	DropLive     DropStatus = "live"
+	DropEnded    DropStatus = "ended"
+)
+
+// StockMovementReason is why a product's stock changed (stock_movements.reason)
+type StockMovementReason string
+
+const (
+	MovementSale            StockMovementReason = "sale"
+	MovementCancellation    StockMovementReason = "cancellation"
+	MovementAdminAdjustment StockMovementReason = "admin_adjustment"
+	MovementReturn          StockMovementReason = "return"
+)
+
+func (r StockMovementReason) IsValid() bool {
+	switch r {
+	case MovementSale, MovementCancellation, MovementAdminAdjustment, MovementReturn:
+		return true
+	}
+	return false
+}
+
+// RestockStatus: a subscription waits while the product is sold out and is fulfilled once emailed
+type RestockStatus string
+
+const (
+	RestockPending   RestockStatus = "pending"
+	RestockFulfilled RestockStatus = "fulfilled"
+)
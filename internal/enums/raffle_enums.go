@@ -0,0 +1,20 @@
+package enums
+
+// RaffleStatus: entries are taken while open, the draw moves it to drawn
+type RaffleStatus string
+
+const (
+	RaffleOpen  RaffleStatus = "open"
+	RaffleDrawn RaffleStatus = "drawn"
+)
+
+// RaffleEntryStatus follows an entry from entering to the winner's purchase
+type RaffleEntryStatus string
+
+const (
+	EntryEntered   RaffleEntryStatus = "entered"
+	EntryWon       RaffleEntryStatus = "won" // holds one unit until the reservation expires
+	EntryLost      RaffleEntryStatus = "lost"
+	EntryPurchased RaffleEntryStatus = "purchased" // reservation turned into an order
+	EntryExpired   RaffleEntryStatus = "expired"   // reservation lapsed, unit went back to stock
+)
@@ -0,0 +1,32 @@
+package helpers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/google/uuid"
+)
+
+// NewRaffleSeed returns 32 random hex characters for a raffle draw
+func NewRaffleSeed() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// RaffleSeedHash is the published commitment to a seed: hex sha256 of the seed. It is shown
+// while the raffle is open, so the seed revealed at the draw can be checked against it.
+func RaffleSeedHash(seed string) string {
+	sum := sha256.Sum256([]byte(seed))
+	return hex.EncodeToString(sum[:])
+}
+
+// RaffleTicket is an entry's draw ticket: hex sha256 of "seed:entryID". Entries are ranked by
+// ticket, so a published seed lets anyone reproduce the draw order.
+func RaffleTicket(seed string, entryID uuid.UUID) string {
+	sum := sha256.Sum256([]byte(seed + ":" + entryID.String()))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,79 @@
+package helpers
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewRaffleSeed(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 20; i++ {
+		seed, err := NewRaffleSeed()
+		if err != nil {
+			t.Fatalf("NewRaffleSeed() error = %v", err)
+		}
+		if len(seed) != 32 {
+			t.Fatalf("NewRaffleSeed() = %q, want 32 characters", seed)
+		}
+		if _, err := hex.DecodeString(seed); err != nil {
+			t.Fatalf("NewRaffleSeed() = %q, not hex: %v", seed, err)
+		}
+		if seen[seed] {
+			t.Fatalf("NewRaffleSeed() returned %q twice", seed)
+		}
+		seen[seed] = true
+	}
+}
+
+func TestRaffleTicket(t *testing.T) {
+	entry := uuid.MustParse("6f1c1a52-3c1e-4a8b-9a57-0d1f4b7c2e10")
+	other := uuid.MustParse("0b7e3f9d-8a2c-4e61-b5d4-93c0a1f2e7d8")
+
+	tests := []struct {
+		name      string
+		seedA     string
+		entryA    uuid.UUID
+		seedB     string
+		entryB    uuid.UUID
+		wantEqual bool
+	}{
+		{"same seed and entry", "seed", entry, "seed", entry, true},
+		{"different entry", "seed", entry, "seed", other, false},
+		{"different seed", "seed", entry, "other-seed", entry, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := RaffleTicket(tt.seedA, tt.entryA)
+			b := RaffleTicket(tt.seedB, tt.entryB)
+
+			if len(a) != 64 {
+				t.Fatalf("RaffleTicket() = %q, want 64 hex characters", a)
+			}
+			if (a == b) != tt.wantEqual {
+				t.Fatalf("RaffleTicket() equal = %v, want %v (%s vs %s)", a == b, tt.wantEqual, a, b)
+			}
+		})
+	}
+}
+
+func TestRaffleTicketIsReproducible(t *testing.T) {
+	// sha256 of "abc:00000000-0000-0000-0000-000000000000": anyone holding the seed gets the same ticket
+	const want = "6d5ef7a25ddc590a141cd71969c1aae7184e4af851f466268b5d97a863a0d5c8"
+	if got := RaffleTicket("abc", uuid.Nil); got != want {
+		t.Fatalf("RaffleTicket() = %q, want %q", got, want)
+	}
+}
+
+func TestRaffleSeedHash(t *testing.T) {
+	// sha256 of "abc", published while the raffle is open
+	const want = "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"
+	if got := RaffleSeedHash("abc"); got != want {
+		t.Fatalf("RaffleSeedHash() = %q, want %q", got, want)
+	}
+	if RaffleSeedHash("abc") == RaffleSeedHash("abd") {
+		t.Fatalf("RaffleSeedHash() is the same for different seeds")
+	}
+}
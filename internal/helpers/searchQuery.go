@@ -0,0 +1,21 @@
+package helpers
+
+import (
+	"strings"
+	"unicode"
+)
+
+// BuildPrefixTSQuery turns free text into a to_tsquery string where every word is a prefix match
+// e.g. "air jor" → "air:* & jor:*". Returns "" when nothing searchable is left.
+func BuildPrefixTSQuery(search string) string {
+	words := strings.FieldsFunc(strings.ToLower(search), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := make([]string, 0, len(words))
+	for _, w := range words {
+		terms = append(terms, w+":*")
+	}
+
+	return strings.Join(terms, " & ")
+}
@@ -0,0 +1,14 @@
+package helpers
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Slugify makes a lowercase, dash separated URL segment, e.g. "Men's Trail Running" → "men-s-trail-running"
+func Slugify(s string) string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, "-")
+}
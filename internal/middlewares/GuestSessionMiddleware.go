@@ -0,0 +1,75 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GuestCookieName holds the anonymous id used for guest state (recently viewed, ...)
+const GuestCookieName = "guest_id"
+
+const guestCookieMaxAge = 30 * 24 * 60 * 60 // 30 days
+
+// GuestSession gives anonymous visitors a stable "guest_id" cookie and sets "GuestID" in the context.
+// Use it after OptionalAuth: logged-in users are left alone.
+func GuestSession() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if userID, exists := ctx.Get("UserID"); exists && userID != nil {
+			ctx.Next()
+			return
+		}
+
+		guestID, ok := GuestID(ctx)
+		if !ok {
+			guestID = uuid.New()
+		}
+
+		// (re)set on every visit so the cookie expiry slides
+		ctx.SetCookie(GuestCookieName, guestID.String(), guestCookieMaxAge, "/", "", false, true)
+		ctx.Set("GuestID", guestID.String())
+
+		ctx.Next()
+	}
+}
+
+// RefreshBeforeGuest answers 401 when a logged-in visitor's access token ran out (the refresh
+// token is still there), so the client refreshes instead of quietly falling back to guest state.
+// A failed refresh clears the refresh_token cookie, after which the visitor continues as a guest.
+// Use it between OptionalAuth and GuestSession on routes that serve guests and users alike.
+func RefreshBeforeGuest() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if userID, exists := ctx.Get("UserID"); exists && userID != nil {
+			ctx.Next()
+			return
+		}
+
+		if refresh, err := ctx.Cookie("refresh_token"); err == nil && refresh != "" {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Access token missing"})
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+// GuestID reads the guest cookie, false when missing or not a valid id
+func GuestID(ctx *gin.Context) (uuid.UUID, bool) {
+	cookie, err := ctx.Cookie(GuestCookieName)
+	if err != nil || cookie == "" {
+		return uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(cookie)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// ClearGuestSession drops the guest cookie once its data has been merged into an account
+func ClearGuestSession(ctx *gin.Context) {
+	ctx.SetCookie(GuestCookieName, "", -1, "/", "", false, true)
+}
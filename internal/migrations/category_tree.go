@@ -0,0 +1,69 @@
+package migrations
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/akhilnasimk/SS_backend/internal/config"
+	"github.com/akhilnasimk/SS_backend/internal/helpers"
+	"github.com/akhilnasimk/SS_backend/internal/models"
+)
+
+// categories used to be a flat, globally unique name list and deleting one cascaded to its products
+var categoryTreeStatements = []string{
+	// names are now unique per parent (idx_category_parent_name)
+	`DROP INDEX IF EXISTS idx_categories_name`,
+
+	// AutoMigrate does not rewrite an existing foreign key, swap CASCADE for RESTRICT by hand
+	`DO $$
+	BEGIN
+		IF EXISTS (
+			SELECT 1 FROM pg_constraint
+			WHERE conname = 'fk_products_category' AND confdeltype = 'c'
+		) THEN
+			ALTER TABLE products DROP CONSTRAINT fk_products_category;
+			ALTER TABLE products ADD CONSTRAINT fk_products_category
+				FOREIGN KEY (category_id) REFERENCES categories(id) ON DELETE RESTRICT;
+		END IF;
+	END
+	$$`,
+}
+
+func setupCategoryTree() {
+	for _, stmt := range categoryTreeStatements {
+		if err := config.DB.Exec(stmt).Error; err != nil {
+			log.Fatal("Category migration failed ", err)
+		}
+	}
+
+	backfillCategorySlugs()
+}
+
+// backfillCategorySlugs gives categories created before slugs existed a unique one
+func backfillCategorySlugs() {
+	var categories []models.Category
+	if err := config.DB.Where("slug IS NULL OR slug = ''").Find(&categories).Error; err != nil {
+		log.Fatal("Category slug backfill failed ", err)
+	}
+
+	for _, c := range categories {
+		base := helpers.Slugify(c.Name)
+		if base == "" {
+			base = "category"
+		}
+
+		slug := base
+		for n := 2; ; n++ {
+			var count int64
+			config.DB.Model(&models.Category{}).Where("slug = ?", slug).Count(&count)
+			if count == 0 {
+				break
+			}
+			slug = fmt.Sprintf("%s-%d", base, n)
+		}
+
+		if err := config.DB.Model(&models.Category{}).Where("id = ?", c.ID).Update("slug", slug).Error; err != nil {
+			log.Fatal("Category slug backfill failed ", err)
+		}
+	}
+}
@@ -11,6 +11,7 @@ func RunMigrations() {
 	err := config.DB.AutoMigrate(
 		&models.User{},
 		&models.Product{},
+		&models.ProductVariant{},
 		&models.Cart{},
 		&models.CartItem{},
 		&models.Category{},
@@ -20,8 +21,28 @@ func RunMigrations() {
 		&models.Wishlist{},
 		&models.RefreshToken{},
 		&models.OTP{},
+		&models.Review{},
+		&models.ReviewPhoto{},
+		&models.ProductQuestion{},
+		&models.ProductAnswer{},
+		&models.ProductCoPurchase{},
+		&models.RecentlyViewed{},
+		&models.SlugRedirect{},
+		&models.Raffle{},
+		&models.RaffleEntry{},
+		&models.StockMovement{},
+		&models.StockHold{},
+		&models.RestockSubscription{},
 	)
 	if err != nil {
 		log.Fatal("Migration failed ", err)
 	}
+
+	setupProductSearch()
+	setupCategoryTree()
+	backfillProductSlugs()
+	backfillOpeningStock()
+	setupStockAlerts()
+	backfillWishlistPrices()
+	commitRaffleSeeds()
 }
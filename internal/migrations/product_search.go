@@ -0,0 +1,54 @@
+package migrations
+
+import (
+	"log"
+
+	"github.com/akhilnasimk/SS_backend/internal/config"
+)
+
+// full-text search over products: a trigger keeps products.search_vector in sync
+// (name = weight A, category name = weight B, description = weight C)
+var productSearchStatements = []string{
+	`CREATE OR REPLACE FUNCTION products_search_vector_refresh() RETURNS trigger AS $$
+	BEGIN
+		NEW.search_vector :=
+			setweight(to_tsvector('simple', coalesce(NEW.name, '')), 'A') ||
+			setweight(to_tsvector('simple', coalesce((SELECT name FROM categories WHERE id = NEW.category_id), '')), 'B') ||
+			setweight(to_tsvector('simple', coalesce(NEW.description, '')), 'C');
+		RETURN NEW;
+	END
+	$$ LANGUAGE plpgsql`,
+
+	`DROP TRIGGER IF EXISTS trg_products_search_vector ON products`,
+
+	`CREATE TRIGGER trg_products_search_vector
+		BEFORE INSERT OR UPDATE ON products
+		FOR EACH ROW EXECUTE FUNCTION products_search_vector_refresh()`,
+
+	// renaming a category must re-index its products
+	`CREATE OR REPLACE FUNCTION categories_search_vector_cascade() RETURNS trigger AS $$
+	BEGIN
+		IF NEW.name IS DISTINCT FROM OLD.name THEN
+			UPDATE products SET search_vector = NULL WHERE category_id = NEW.id;
+		END IF;
+		RETURN NEW;
+	END
+	$$ LANGUAGE plpgsql`,
+
+	`DROP TRIGGER IF EXISTS trg_categories_search_vector ON categories`,
+
+	`CREATE TRIGGER trg_categories_search_vector
+		AFTER UPDATE ON categories
+		FOR EACH ROW EXECUTE FUNCTION categories_search_vector_cascade()`,
+
+	// backfill rows created before the trigger existed
+	`UPDATE products SET search_vector = NULL WHERE search_vector IS NULL`,
+}
+
+func setupProductSearch() {
+	for _, stmt := range productSearchStatements {
+		if err := config.DB.Exec(stmt).Error; err != nil {
+			log.Fatal("Product search migration failed ", err)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/akhilnasimk/SS_backend/internal/config"
+	"github.com/akhilnasimk/SS_backend/internal/helpers"
+	"github.com/akhilnasimk/SS_backend/internal/models"
+)
+
+// backfillProductSlugs gives products created before slugs existed a unique one (trashed ones too)
+func backfillProductSlugs() {
+	var products []models.Product
+	if err := config.DB.Unscoped().Select("id", "name").Where("slug IS NULL OR slug = ''").Find(&products).Error; err != nil {
+		log.Fatal("Product slug backfill failed ", err)
+	}
+
+	for _, p := range products {
+		base := helpers.Slugify(p.Name)
+		if base == "" {
+			base = "product"
+		}
+
+		slug := base
+		for n := 2; ; n++ {
+			var count int64
+			config.DB.Unscoped().Model(&models.Product{}).Where("slug = ?", slug).Count(&count)
+			if count == 0 {
+				break
+			}
+			slug = fmt.Sprintf("%s-%d", base, n)
+		}
+
+		if err := config.DB.Unscoped().Model(&models.Product{}).Where("id = ?", p.ID).Update("slug", slug).Error; err != nil {
+			log.Fatal("Product slug backfill failed ", err)
+		}
+	}
+}
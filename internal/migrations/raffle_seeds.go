@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"log"
+
+	"github.com/akhilnasimk/SS_backend/internal/config"
+	"github.com/akhilnasimk/SS_backend/internal/enums"
+	"github.com/akhilnasimk/SS_backend/internal/helpers"
+	"github.com/akhilnasimk/SS_backend/internal/models"
+)
+
+// commitRaffleSeeds gives open raffles created before seeds were committed up front their seed
+// and published hash, so they can still be drawn
+func commitRaffleSeeds() {
+	var raffles []models.Raffle
+	if err := config.DB.Select("id").Where("status = ? AND (seed IS NULL OR seed = '')", enums.RaffleOpen).Find(&raffles).Error; err != nil {
+		log.Fatal("Raffle seed backfill failed ", err)
+	}
+
+	for _, r := range raffles {
+		seed, err := helpers.NewRaffleSeed()
+		if err != nil {
+			log.Fatal("Raffle seed backfill failed ", err)
+		}
+
+		if err := config.DB.Model(&models.Raffle{}).Where("id = ?", r.ID).Updates(map[string]interface{}{
+			"seed":      seed,
+			"seed_hash": helpers.RaffleSeedHash(seed),
+		}).Error; err != nil {
+			log.Fatal("Raffle seed backfill failed ", err)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"log"
+
+	"github.com/akhilnasimk/SS_backend/internal/config"
+)
+
+// low-stock alerts fire once per product / sold-out size; these triggers re-arm them
+// whenever stock is raised back above the threshold, whatever path raised it
+var stockAlertStatements = []string{
+	`CREATE OR REPLACE FUNCTION products_low_stock_rearm() RETURNS trigger AS $$
+	BEGIN
+		IF NEW.stock_count > NEW.low_stock_threshold THEN
+			NEW.low_stock_alerted_at := NULL;
+		END IF;
+		RETURN NEW;
+	END
+	$$ LANGUAGE plpgsql`,
+
+	`DROP TRIGGER IF EXISTS trg_products_low_stock_rearm ON products`,
+
+	`CREATE TRIGGER trg_products_low_stock_rearm
+		BEFORE UPDATE OF stock_count, low_stock_threshold ON products
+		FOR EACH ROW EXECUTE FUNCTION products_low_stock_rearm()`,
+
+	`CREATE OR REPLACE FUNCTION product_variants_sold_out_rearm() RETURNS trigger AS $$
+	BEGIN
+		IF NEW.stock_count > 0 THEN
+			NEW.sold_out_alerted_at := NULL;
+		END IF;
+		RETURN NEW;
+	END
+	$$ LANGUAGE plpgsql`,
+
+	`DROP TRIGGER IF EXISTS trg_product_variants_sold_out_rearm ON product_variants`,
+
+	`CREATE TRIGGER trg_product_variants_sold_out_rearm
+		BEFORE UPDATE OF stock_count ON product_variants
+		FOR EACH ROW EXECUTE FUNCTION product_variants_sold_out_rearm()`,
+}
+
+func setupStockAlerts() {
+	for _, stmt := range stockAlertStatements {
+		if err := config.DB.Exec(stmt).Error; err != nil {
+			log.Fatal("Stock alert migration failed ", err)
+		}
+	}
+}
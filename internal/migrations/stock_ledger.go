@@ -0,0 +1,23 @@
+package migrations
+
+import (
+	"log"
+
+	"github.com/akhilnasimk/SS_backend/internal/config"
+)
+
+// backfillOpeningStock starts the ledger of products that existed before it with one
+// "opening balance" line for their current stock (trashed ones too). Products that already
+// have movements are left alone, so real drift still shows up in the reconciliation.
+func backfillOpeningStock() {
+	err := config.DB.Exec(`
+		INSERT INTO stock_movements (product_id, delta, reason, reference_type, reference_id, note, created_at)
+		SELECT p.id, p.stock_count, 'admin_adjustment', 'product', p.id, 'opening balance', NOW()
+		FROM products p
+		WHERE p.stock_count <> 0
+		  AND NOT EXISTS (SELECT 1 FROM stock_movements m WHERE m.product_id = p.id)
+	`).Error
+	if err != nil {
+		log.Fatal("Opening stock backfill failed ", err)
+	}
+}
@@ -0,0 +1,20 @@
+package migrations
+
+import (
+	"log"
+
+	"github.com/akhilnasimk/SS_backend/internal/config"
+)
+
+// backfillWishlistPrices gives wishlist rows from before price_at_add the product's current price,
+// so their price change starts at zero
+func backfillWishlistPrices() {
+	err := config.DB.Exec(`
+		UPDATE wishlists w SET price_at_add = p.price
+		FROM products p
+		WHERE p.id = w.product_id AND w.price_at_add = 0
+	`).Error
+	if err != nil {
+		log.Fatal("Wishlist price backfill failed ", err)
+	}
+}
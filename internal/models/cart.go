@@ -6,10 +6,14 @@ import (
 	"github.com/google/uuid"
 )
 
+// Cart belongs to a user, or to an anonymous guest cookie until the guest logs in
 type Cart struct {
-	ID     uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey;index" json:"id"`
-	UserID uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
-	User   *User     `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	ID uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey;index" json:"id"`
+
+	// exactly one of UserID / GuestID is set
+	UserID  *uuid.UUID `gorm:"type:uuid;index" json:"user_id,omitempty"`
+	User    *User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	GuestID *uuid.UUID `gorm:"type:uuid;index" json:"-"`
 
 	// Relationship to Cart Items
 	CartItems []CartItem `gorm:"foreignKey:CartID;constraint:OnDelete:CASCADE" json:"items"`
@@ -12,6 +12,10 @@ type CartItem struct {
 	ProductID uuid.UUID `gorm:"type:uuid;not null;index" json:"product_id"`
 	Product   *Product  `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE" json:"product"`
 
+	// Selected size/colorway (nil for products without variants)
+	VariantID *uuid.UUID      `gorm:"type:uuid;index" json:"variant_id"`
+	Variant   *ProductVariant `gorm:"foreignKey:VariantID;constraint:OnDelete:CASCADE" json:"variant,omitempty"`
+
 	Quantity int `gorm:"default:1" json:"quantity"`
 	// Auto timestamps (GORM handles these)
 	CreatedAt time.Time `json:"created_at"`
@@ -7,7 +7,16 @@ import (
 )
 
 type Category struct {
-	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey;index"`
-	Name      string    `gorm:"uniqueIndex;not null" json:"name"`
+	ID   uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey;index"`
+	Name string    `gorm:"not null;uniqueIndex:idx_category_parent_name,priority:2" json:"name"`
+	Slug string    `gorm:"type:varchar(150);uniqueIndex" json:"slug"`
+
+	// Hierarchy, e.g. Men > Running > Trail (nil = top level)
+	ParentID *uuid.UUID `gorm:"type:uuid;index;uniqueIndex:idx_category_parent_name,priority:1" json:"parent_id"`
+	Parent   *Category  `gorm:"foreignKey:ParentID;constraint:OnDelete:RESTRICT" json:"-"`
+
+	DisplayOrder int `gorm:"not null;default:0" json:"display_order"`
+
 	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProductCoPurchase is a precomputed "bought together" pair: how many non-cancelled orders
+// contained both products. Rebuilt periodically from order_items, never written per request.
+type ProductCoPurchase struct {
+	ProductID        uuid.UUID `gorm:"type:uuid;primaryKey;index:idx_copurchase_rank,priority:1" json:"product_id"`
+	Product          *Product  `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE" json:"-"`
+	RelatedProductID uuid.UUID `gorm:"type:uuid;primaryKey" json:"related_product_id"`
+	RelatedProduct   *Product  `gorm:"foreignKey:RelatedProductID;constraint:OnDelete:CASCADE" json:"-"`
+	OrderCount       int       `gorm:"not null;index:idx_copurchase_rank,priority:2,sort:desc" json:"order_count"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"time"
 
+	constent "github.com/akhilnasimk/SS_backend/internal/const"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -13,6 +14,11 @@ type ProductImage struct {
 	URL       string    `json:"url"`
 	AltText   string    `json:"alt_text"`
 
+	// Resized copies generated on upload (empty for images created before, or imported by URL)
+	ThumbnailURL string `json:"thumbnail_url"`
+	CardURL      string `json:"card_url"`
+	ZoomURL      string `json:"zoom_url"`
+
 	// Optional: priority for ordering images
 	Priority int `json:"priority"`
 
@@ -21,3 +27,32 @@ type ProductImage struct {
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 }
+
+// SizedURL returns the derivative for size (constent.ImageSize*), falling back to the original
+func (img ProductImage) SizedURL(size string) string {
+	var url string
+	switch size {
+	case constent.ImageSizeThumbnail:
+		url = img.ThumbnailURL
+	case constent.ImageSizeCard:
+		url = img.CardURL
+	case constent.ImageSizeZoom:
+		url = img.ZoomURL
+	}
+
+	if url == "" {
+		return img.URL
+	}
+	return url
+}
+
+// AllURLs lists the original and every stored derivative, for deleting them from media storage
+func (img ProductImage) AllURLs() []string {
+	urls := []string{img.URL}
+	for _, u := range []string{img.ThumbnailURL, img.CardURL, img.ZoomURL} {
+		if u != "" {
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
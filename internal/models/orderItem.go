@@ -16,9 +16,16 @@ type OrderItem struct {
 	ProductID uuid.UUID `gorm:"type:uuid;index" json:"product_id"`
 	Product   *Product  `gorm:"foreignKey:ProductID;constraint:OnDelete:SET NULL;" json:"-"`
 
+	// Variant reference (nil for products without variants)
+	VariantID *uuid.UUID      `gorm:"type:uuid;index" json:"variant_id"`
+	Variant   *ProductVariant `gorm:"foreignKey:VariantID;constraint:OnDelete:SET NULL;" json:"-"`
+
 	// SNAPSHOT FIELDS
 	ProductName  string `gorm:"not null" json:"product_name"`
 	ProductImage string `json:"product_image"`
+	Size         string `json:"size,omitempty"`
+	Colorway     string `json:"colorway,omitempty"`
+	SKU          string `json:"sku,omitempty"`
 
 	Quantity   int     `json:"quantity"`
 	Price      float64 `json:"price"`
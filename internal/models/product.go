@@ -3,26 +3,71 @@ package models
 import (
 	"time"
 
+	"github.com/akhilnasimk/SS_backend/internal/enums"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
 type Product struct {
-	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey;index:idx_product_keyset,priority:2" json:"id"`
 	Name        string    `gorm:"type:varchar(255);not null;index:idx_product_name_lc" json:"name"`
 	Description string    `gorm:"type:text" json:"description"`
 	Price       int64     `gorm:"not null;index" json:"price"`
 	StockCount  int       `gorm:"not null" json:"stock_count"`
-	IsActive    bool      `gorm:"default:true;index" json:"is_active"`
+	IsActive    bool      `gorm:"not null;index" json:"is_active"` // no DB default, a false value must reach the insert
+
+	// SEO: unique URL slug (old ones keep resolving through slug_redirects) and meta tags
+	Slug            string `gorm:"type:varchar(255);uniqueIndex" json:"slug"`
+	MetaTitle       string `gorm:"type:varchar(150)" json:"meta_title"`
+	MetaDescription string `gorm:"type:varchar(320)" json:"meta_description"`
+
+	// Full-text search document (name, category name, description), kept up to date by a DB trigger
+	SearchVector string `gorm:"type:tsvector;->:false;index:idx_product_search,type:gin" json:"-"`
+
+	// Timed drop: unavailable before ReleaseAt and from EndsAt on (both optional), checked at
+	// read time so no job has to flip IsActive
+	ReleaseAt *time.Time `gorm:"index" json:"release_at"`
+	EndsAt    *time.Time `json:"ends_at"`
+
+	// Optional cap on units one customer can buy within the purchase limit window (nil = no cap)
+	MaxPerCustomer *int `json:"max_per_customer"`
+
+	// Admins get a digest when stock falls to LowStockThreshold or below (0 = only when sold out).
+	// LowStockAlertedAt suppresses repeats; a DB trigger clears it once stock is back above the threshold.
+	LowStockThreshold int        `gorm:"not null;default:0" json:"low_stock_threshold"`
+	LowStockAlertedAt *time.Time `json:"low_stock_alerted_at,omitempty"`
+
+	// Denormalized from visible reviews, kept in sync by the review repository
+	AverageRating float64 `gorm:"type:numeric(3,2);not null;default:0;index" json:"average_rating"`
+	ReviewCount   int     `gorm:"not null;default:0" json:"review_count"`
 
 	// Category Relation
 	CategoryID uuid.UUID `gorm:"type:uuid;not null;index" json:"category_id"`
-	Category   Category  `gorm:"foreignKey:CategoryID;constraint:OnDelete:CASCADE" json:"-"`
+	Category   Category  `gorm:"foreignKey:CategoryID;constraint:OnDelete:RESTRICT" json:"-"`
 
 	// Images Relation
 	Images []ProductImage `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE" json:"images"`
 
-	CreatedAt time.Time      `json:"created_at" gorm:"index"`
+	// Size / colorway variants (SKUs)
+	Variants []ProductVariant `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE" json:"variants,omitempty"`
+
+	CreatedAt time.Time      `json:"created_at" gorm:"index;index:idx_product_keyset,priority:1"` // (created_at, id) keyset pagination
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 }
+
+// DropStatus places the product in its release window at t
+func (p Product) DropStatus(t time.Time) enums.DropStatus {
+	if p.ReleaseAt != nil && t.Before(*p.ReleaseAt) {
+		return enums.DropUpcoming
+	}
+	if p.EndsAt != nil && !t.Before(*p.EndsAt) {
+		return enums.DropEnded
+	}
+	return enums.DropLive
+}
+
+// IsAvailableAt tells if the product can be listed, carted and ordered at t
+func (p Product) IsAvailableAt(t time.Time) bool {
+	return p.IsActive && p.DropStatus(t) == enums.DropLive
+}
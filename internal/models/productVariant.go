@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProductVariant is a sellable size/colorway of a product (a SKU)
+type ProductVariant struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	ProductID uuid.UUID `gorm:"type:uuid;not null;index" json:"product_id"`
+
+	Size     string `gorm:"type:varchar(20);not null" json:"size"`
+	Colorway string `gorm:"type:varchar(100)" json:"colorway"`
+	SKU      string `gorm:"type:varchar(64);not null;uniqueIndex" json:"sku"`
+
+	StockCount int `gorm:"not null;default:0" json:"stock_count"`
+	// Optional: when set it replaces the product price for this variant
+	PriceOverride *int64 `json:"price_override,omitempty"`
+	// no DB default: gorm would skip a false value on insert and store the default instead
+	IsActive bool `gorm:"not null" json:"is_active"`
+	// Optional per-size cap, on top of the product's MaxPerCustomer
+	MaxPerCustomer *int `json:"max_per_customer,omitempty"`
+	// Set when admins were told this size sold out, cleared by a DB trigger on restock
+	SoldOutAlertedAt *time.Time `json:"-"`
+
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// EffectivePrice returns the override price if present, else the product price
+func (v ProductVariant) EffectivePrice(productPrice int64) int64 {
+	if v.PriceOverride != nil {
+		return *v.PriceOverride
+	}
+	return productPrice
+}
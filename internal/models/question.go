@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProductQuestion is a customer's public question on a product page
+type ProductQuestion struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	ProductID uuid.UUID `gorm:"type:uuid;not null;index:idx_question_product_created,priority:1" json:"product_id"`
+	Product   *Product  `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE" json:"-"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	User      *User     `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+
+	Body string `gorm:"type:text;not null" json:"body"`
+
+	Answers []ProductAnswer `gorm:"foreignKey:QuestionID;constraint:OnDelete:CASCADE" json:"answers"`
+
+	CreatedAt time.Time `gorm:"index:idx_question_product_created,priority:2" json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ProductAnswer is posted by an admin or a verified buyer of the product
+type ProductAnswer struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	QuestionID uuid.UUID `gorm:"type:uuid;not null;index" json:"question_id"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;index" json:"user_id"`
+	User       *User     `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+
+	Body string `gorm:"type:text;not null" json:"body"`
+
+	// how the answerer qualified, shown as a badge next to the answer
+	IsAdmin         bool `gorm:"not null;default:false" json:"is_admin"`
+	IsVerifiedBuyer bool `gorm:"not null;default:false" json:"is_verified_buyer"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
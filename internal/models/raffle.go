@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/enums"
+	"github.com/google/uuid"
+)
+
+// Raffle replaces first-come checkout for a limited release: entries are taken during
+// [OpensAt, ClosesAt), then a seeded draw picks winners up to the available stock.
+// The seed is generated when the raffle is created and kept secret until the draw; only its
+// hash is public before that, so nobody can pick a seed that favours a known entry.
+type Raffle struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	ProductID uuid.UUID `gorm:"type:uuid;not null;index" json:"product_id"`
+	Product   *Product  `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE" json:"-"`
+
+	OpensAt  time.Time          `gorm:"not null" json:"opens_at"`
+	ClosesAt time.Time          `gorm:"not null;index" json:"closes_at"`
+	Status   enums.RaffleStatus `gorm:"type:varchar(20);not null;default:'open';index" json:"status"`
+
+	// how long a winner's purchase reservation lasts
+	ReservationHours int `gorm:"not null;default:24" json:"reservation_hours"`
+
+	// Draw audit trail: with the seed and the entry ids anyone can recompute the ranking
+	Seed        string     `gorm:"type:varchar(128)" json:"-"`
+	SeedHash    string     `gorm:"type:varchar(64)" json:"seed_hash"`
+	DrawnAt     *time.Time `json:"drawn_at,omitempty"`
+	DrawnBy     *uuid.UUID `gorm:"type:uuid" json:"drawn_by,omitempty"`
+	WinnerCount int        `gorm:"not null;default:0" json:"winner_count"`
+
+	Entries []RaffleEntry `gorm:"foreignKey:RaffleID;constraint:OnDelete:CASCADE" json:"-"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RaffleEntry is one account's entry for a size, at most one per raffle
+type RaffleEntry struct {
+	ID        uuid.UUID       `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	RaffleID  uuid.UUID       `gorm:"type:uuid;not null;uniqueIndex:idx_raffle_entry_user,priority:1" json:"raffle_id"`
+	UserID    uuid.UUID       `gorm:"type:uuid;not null;uniqueIndex:idx_raffle_entry_user,priority:2;index" json:"user_id"`
+	User      *User           `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	ProductID uuid.UUID       `gorm:"type:uuid;not null" json:"product_id"`
+	VariantID *uuid.UUID      `gorm:"type:uuid" json:"variant_id"`
+	Variant   *ProductVariant `gorm:"foreignKey:VariantID;constraint:OnDelete:SET NULL" json:"-"`
+
+	Status enums.RaffleEntryStatus `gorm:"type:varchar(20);not null;default:'entered';index" json:"status"`
+
+	// set by the draw: Ticket = sha256(seed:entry id), entries rank by ticket
+	Ticket       string `gorm:"type:varchar(64)" json:"ticket,omitempty"`
+	DrawPosition *int   `json:"draw_position,omitempty"`
+
+	// winners only
+	ReservationExpiresAt *time.Time `gorm:"index" json:"reservation_expires_at,omitempty"`
+	OrderID              *uuid.UUID `gorm:"type:uuid" json:"order_id,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
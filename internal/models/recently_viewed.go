@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecentlyViewed is one product view, owned by a user or by an anonymous guest cookie.
+// One row per owner and product, ViewedAt is bumped on every new view.
+type RecentlyViewed struct {
+	ID uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+
+	// exactly one of UserID / GuestID is set
+	UserID  *uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_recent_user_product,priority:1" json:"user_id,omitempty"`
+	User    *User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	GuestID *uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_recent_guest_product,priority:1" json:"-"`
+
+	ProductID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_recent_user_product,priority:2;uniqueIndex:idx_recent_guest_product,priority:2" json:"product_id"`
+	Product   *Product  `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE" json:"product,omitempty"`
+
+	ViewedAt time.Time `gorm:"not null;index" json:"viewed_at"`
+}
+
+func (RecentlyViewed) TableName() string {
+	return "recently_viewed"
+}
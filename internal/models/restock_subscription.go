@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/enums"
+	"github.com/google/uuid"
+)
+
+// RestockSubscription asks for one email when a sold-out product is back in stock.
+// There is one row per customer and product; subscribing again re-arms a fulfilled row.
+type RestockSubscription struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	ProductID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_restock_product_user,priority:1" json:"product_id"`
+	Product   *Product  `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE" json:"-"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_restock_product_user,priority:2;index" json:"user_id"`
+	User      *User     `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+
+	Status     enums.RestockStatus `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"`
+	NotifiedAt *time.Time          `json:"notified_at,omitempty"`
+
+	// queue position: oldest subscriptions are emailed first
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+}
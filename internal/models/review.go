@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Review is a verified buyer's rating of a product, one per user per product
+type Review struct {
+	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	ProductID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_review_product_user,priority:1" json:"product_id"`
+	Product   *Product  `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE" json:"-"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_review_product_user,priority:2;index" json:"user_id"`
+	User      *User     `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+
+	Rating int    `gorm:"not null;check:chk_review_rating,rating BETWEEN 1 AND 5" json:"rating"`
+	Title  string `gorm:"type:varchar(150)" json:"title"`
+	Body   string `gorm:"type:text" json:"body"`
+
+	// Moderation: hidden reviews are left out of listings and the product rating
+	IsHidden     bool   `gorm:"not null;default:false;index" json:"is_hidden"`
+	HiddenReason string `gorm:"type:varchar(255)" json:"hidden_reason,omitempty"`
+
+	Photos []ReviewPhoto `gorm:"foreignKey:ReviewID;constraint:OnDelete:CASCADE" json:"photos"`
+
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ReviewPhoto keeps the same derivative sizes as product images
+type ReviewPhoto struct {
+	ID           uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	ReviewID     uuid.UUID `gorm:"type:uuid;not null;index" json:"review_id"`
+	URL          string    `gorm:"not null" json:"url"`
+	ThumbnailURL string    `json:"thumbnail_url"`
+	CardURL      string    `json:"card_url"`
+	ZoomURL      string    `json:"zoom_url"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// AllURLs lists the original and every stored derivative
+func (p ReviewPhoto) AllURLs() []string {
+	return ProductImage{URL: p.URL, ThumbnailURL: p.ThumbnailURL, CardURL: p.CardURL, ZoomURL: p.ZoomURL}.AllURLs()
+}
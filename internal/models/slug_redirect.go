@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SlugRedirect keeps an old slug pointing at its product or category after a rename
+type SlugRedirect struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	EntityType string    `gorm:"type:varchar(20);not null;uniqueIndex:idx_slug_redirect_old,priority:1" json:"entity_type"`
+	OldSlug    string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_slug_redirect_old,priority:2" json:"old_slug"`
+	EntityID   uuid.UUID `gorm:"type:uuid;not null;index" json:"entity_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
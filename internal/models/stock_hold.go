@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StockHold reserves units of a cart line for the customer while they check out.
+// Holds stop counting at ExpiresAt; expired rows are only cleaned up later.
+type StockHold struct {
+	ID        uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	UserID    uuid.UUID  `gorm:"type:uuid;not null;index" json:"user_id"`
+	ProductID uuid.UUID  `gorm:"type:uuid;not null;index:idx_stock_hold_product,priority:1" json:"product_id"`
+	VariantID *uuid.UUID `gorm:"type:uuid;index" json:"variant_id,omitempty"`
+	Quantity  int        `gorm:"not null" json:"quantity"`
+
+	ExpiresAt time.Time `gorm:"not null;index;index:idx_stock_hold_product,priority:2" json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/enums"
+	"github.com/google/uuid"
+)
+
+// StockMovement is one append-only line of the inventory ledger: every change to
+// products.stock_count is written here in the same transaction, so the sum of Delta per
+// product equals its StockCount. There is deliberately no FK to products: the history
+// outlives a purged product.
+type StockMovement struct {
+	ID        uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	ProductID uuid.UUID  `gorm:"type:uuid;not null;index:idx_stock_movement_product,priority:1" json:"product_id"`
+	VariantID *uuid.UUID `gorm:"type:uuid" json:"variant_id,omitempty"` // the size the units belong to, when any
+
+	Delta  int                       `gorm:"not null" json:"delta"`
+	Reason enums.StockMovementReason `gorm:"type:varchar(30);not null;index" json:"reason"`
+
+	// what caused it (ReferenceType is one of constent.StockRef*) and who did (nil = system)
+	ReferenceType string     `gorm:"type:varchar(30)" json:"reference_type,omitempty"`
+	ReferenceID   *uuid.UUID `gorm:"type:uuid;index" json:"reference_id,omitempty"`
+	ActorID       *uuid.UUID `gorm:"type:uuid" json:"actor_id,omitempty"`
+	Note          string     `gorm:"type:varchar(255)" json:"note,omitempty"`
+
+	CreatedAt time.Time `gorm:"index:idx_stock_movement_product,priority:2" json:"created_at"`
+}
@@ -8,7 +8,7 @@ import (
 )
 
 type User struct {
-	ID        uuid.UUID      `gorm:"type:uuid;default:uuid_generate_v4();primaryKey" json:"id"`
+	ID        uuid.UUID      `gorm:"type:uuid;default:uuid_generate_v4();primaryKey;index:idx_user_keyset,priority:2" json:"id"`
 	UserName  string         `json:"username" gorm:"type:varchar(100);not null"`
 	Email     string         `json:"email" gorm:"uniqueIndex;type:varchar(100);not null"`
 	Password  string         `json:"password" gorm:"not null"`
@@ -17,11 +17,14 @@ type User struct {
 	Address   *string        `json:"address,omitempty"`
 	IsAdmin   bool           `json:"is_admin" gorm:"default:false"`
 	IsBlocked bool           `json:"is_blocked" gorm:"default:false"`
-	CreatedAt time.Time      `json:"created_at"`
+	CreatedAt time.Time      `json:"created_at" gorm:"index:idx_user_keyset,priority:1"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 	UserRole  *string        `gorm:"default:'customer'"`
 
+	// Opt-out for wishlist price-drop emails
+	PriceDropAlerts bool `json:"price_drop_alerts" gorm:"not null;default:true"`
+
 	// Relationships
 	Cart      Cart       `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"cart"`
 	Orders    []Order    `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"orders"`
@@ -12,5 +12,9 @@ type Wishlist struct {
 	User      User      `gorm:"foreignKey:UserID" json:"user"`
 	ProductID uuid.UUID `gorm:"type:uuid;not null;index:idx_user_product,unique" json:"product_id"`
 	Product   Product   `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE" json:"product"`
-	CreatedAt time.Time `json:"created_at"`
+	// product price when it was wishlisted, to show the change since
+	PriceAtAdd int64 `gorm:"not null;default:0" json:"price_at_add"`
+	// price in the last price-drop email, nil until one was sent
+	LastNotifiedPrice *int64    `json:"-"`
+	CreatedAt         time.Time `json:"created_at"`
 }
@@ -1,13 +1,19 @@
 package interfaces
 
 import (
+	"time"
+
 	"github.com/akhilnasimk/SS_backend/internal/models"
 	"github.com/google/uuid"
 )
 
+// CartRepository methods that take an owner use its UserID, or its GuestID for guest carts
 type CartRepository interface {
 	FindAllcartItemsOfUser(userID uuid.UUID) (models.Cart, error)
-	AddItemToCart(userID uuid.UUID, productID uuid.UUID) (*models.CartItem, error)
-	PatchQuantity(id uuid.UUID, op string) error
-	HardDeleteCartItem(id uuid.UUID) error
+	FindGuestCart(guestID uuid.UUID) (models.Cart, error)
+	AddItemToCart(owner models.Cart, productID uuid.UUID, variantID *uuid.UUID, limitSince time.Time) (*models.CartItem, error)
+	PatchQuantity(owner models.Cart, id uuid.UUID, op string, limitSince time.Time) error
+	HardDeleteCartItem(owner models.Cart, id uuid.UUID) error
+	MergeGuestCart(guestID, userID uuid.UUID) error
+	DeleteStaleGuestCarts(before time.Time) (int64, error)
 }
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/google/uuid"
+)
+
+type CategoryRepository interface {
+	FindAll() ([]models.Category, error)
+	FindByID(id uuid.UUID) (*models.Category, error)
+	FindBySlug(slug string) (*models.Category, error)
+	SlugExists(slug string, excludeID uuid.UUID) (bool, error)
+	NameExists(name string, parentID *uuid.UUID, excludeID uuid.UUID) (bool, error)
+	IsDescendant(categoryID, ancestorID uuid.UUID) (bool, error)
+	CountProducts(id uuid.UUID) (int64, error)
+	Create(category *models.Category) error
+	Update(category *models.Category) error
+	Delete(id uuid.UUID, reassignTo *uuid.UUID) error
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Keyset is the (created_at, id) of the last row already returned.
+// Listings ordered by created_at DESC, id DESC continue strictly after it.
+type Keyset struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
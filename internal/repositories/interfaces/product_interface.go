@@ -1,18 +1,63 @@
 package interfaces
 
 import (
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/enums"
 	"github.com/akhilnasimk/SS_backend/internal/models"
 	"github.com/google/uuid"
 )
 
+// FacetCount is a single bucket of a listing facet
+type FacetCount struct {
+	Key   string
+	Label string
+	Count int64
+}
+
+// ProductFacetCounts holds the facet buckets for a filtered product listing
+type ProductFacetCounts struct {
+	Categories   []FacetCount
+	PriceBuckets []FacetCount
+	Availability []FacetCount
+}
+
 type ProductsRepository interface {
-	GetAllProducts(limit int, offset int, categoryID string, search string, minPrice int64, maxPrice int64, includeDeleted bool) ([]models.Product, int64, error)
+	GetAllProducts(limit int, offset int, categoryID string, search string, minPrice int64, maxPrice int64, includeDeleted bool, sort enums.ProductSort) ([]models.Product, int64, error)
+	GetProductsByCursor(limit int, after *Keyset, categoryID string, search string, minPrice int64, maxPrice int64, includeDeleted bool) ([]models.Product, error)
+	GetProductFacets(categoryID string, search string, minPrice int64, maxPrice int64, includeDeleted bool) (ProductFacetCounts, error)
 	ProductById(id uuid.UUID) (models.Product, error)
-	CreateProductWithImages(product models.Product, images []models.ProductImage) (models.Product, error)
+	ProductBySlug(slug string) (models.Product, error)
+	SlugExists(slug string, excludeID uuid.UUID) (bool, error)
+	FindUpcoming(now time.Time, limit int) ([]models.Product, error)
+	CreateProductWithImages(product models.Product, images []models.ProductImage, actorID uuid.UUID) (models.Product, error)
 	FindAllCategory() ([]models.Category, error)
-	UpdateProduct(product *models.Product) error
+	UpdateProduct(product *models.Product, actorID uuid.UUID) error
 	DeleteImagesNotIn(productID uuid.UUID, urlsToKeep []string) ([]string, error)
 	FindById(id uuid.UUID) (*models.Product, error)
 	ToggleActive(id uuid.UUID) error
 	DeleteProduct(id uuid.UUID) error
+	RestoreProduct(id uuid.UUID) error
+	FindDeletedProducts(limit, offset int) ([]models.Product, int64, error)
+	PurgeDeletedBefore(cutoff time.Time) (int64, []string, error)
+
+	// image ordering (priority 0 = primary) and alt text
+	FindImages(productID uuid.UUID) ([]models.ProductImage, error)
+	ReorderImages(productID uuid.UUID, imageIDs []uuid.UUID) error
+	SetPrimaryImage(productID, imageID uuid.UUID) error
+	UpdateImageAltText(productID, imageID uuid.UUID, altText string) error
+
+	// bulk import / export
+	Transaction(fn func(repo ProductsRepository) error) error
+	FindByName(name string) (*models.Product, error)
+	FindAllForExport() ([]models.Product, error)
+
+	// variants (size / colorway SKUs)
+	FindVariantsByProduct(productID uuid.UUID) ([]models.ProductVariant, error)
+	FindVariantByID(productID, variantID uuid.UUID) (*models.ProductVariant, error)
+	CreateVariant(variant *models.ProductVariant, actorID uuid.UUID) error
+	UpdateVariant(variant *models.ProductVariant, actorID uuid.UUID) error
+
+	// units on hold at checkout, per product and per variant
+	HeldStock(productIDs []uuid.UUID, now time.Time) (map[uuid.UUID]int, map[uuid.UUID]int, error)
 }
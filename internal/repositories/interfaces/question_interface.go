@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/google/uuid"
+)
+
+type QuestionRepository interface {
+	CreateQuestion(question *models.ProductQuestion) error
+	FindQuestionByID(id uuid.UUID) (*models.ProductQuestion, error)
+	FindByProduct(productID uuid.UUID, limit, offset int) ([]models.ProductQuestion, int64, error)
+	CreateAnswer(answer *models.ProductAnswer) error
+}
@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/google/uuid"
+)
+
+type RaffleRepository interface {
+	Create(raffle *models.Raffle) error
+	FindByID(id uuid.UUID) (*models.Raffle, error)
+	FindOpen(now time.Time) ([]models.Raffle, error)
+	CountEntries(raffleID uuid.UUID) (int64, error)
+
+	CreateEntry(entry *models.RaffleEntry, now time.Time) error
+	FindEntry(raffleID, userID uuid.UUID) (*models.RaffleEntry, error)
+	FindEntries(raffleID uuid.UUID) ([]models.RaffleEntry, error)
+
+	Draw(raffleID uuid.UUID, drawnBy uuid.UUID, now time.Time) (*models.Raffle, []models.RaffleEntry, error)
+	PurchaseReservation(raffleID, userID uuid.UUID, order *models.Order, now time.Time) error
+	ExpireReservations(now time.Time) (int64, error)
+}
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/google/uuid"
+)
+
+type RecentlyViewedRepository interface {
+	RecordView(view *models.RecentlyViewed, keep int) error
+	FindByUser(userID uuid.UUID, limit int) ([]models.RecentlyViewed, error)
+	FindByGuest(guestID uuid.UUID, limit int) ([]models.RecentlyViewed, error)
+	MergeGuest(guestID, userID uuid.UUID, keep int) error
+}
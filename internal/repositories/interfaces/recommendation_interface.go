@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/google/uuid"
+)
+
+type RecommendationRepository interface {
+	RefreshCoPurchases() (int64, error)
+	FindCoPurchased(productID uuid.UUID, limit int) ([]models.Product, error)
+}
@@ -0,0 +1,24 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// RestockNotice is a claimed subscription with what its email needs
+type RestockNotice struct {
+	SubscriptionID uuid.UUID
+	Email          string
+	UserName       string
+	ProductID      uuid.UUID
+	ProductName    string
+}
+
+type RestockRepository interface {
+	Subscribe(sub *models.RestockSubscription) error
+	Unsubscribe(userID, productID uuid.UUID) (int64, error)
+	FindSubscription(userID, productID uuid.UUID) (*models.RestockSubscription, error)
+	ClaimBatch(limit int, now time.Time) ([]RestockNotice, error)
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/google/uuid"
+)
+
+type ReviewRepository interface {
+	Create(review *models.Review) error
+	Update(review *models.Review) error
+	FindByID(id uuid.UUID) (*models.Review, error)
+	FindByUserAndProduct(userID, productID uuid.UUID) (*models.Review, error)
+	FindByProduct(productID uuid.UUID, limit, offset int, includeHidden bool) ([]models.Review, int64, error)
+	SetHidden(id uuid.UUID, hidden bool, reason string) (*models.Review, error)
+}
@@ -0,0 +1,7 @@
+package interfaces
+
+import "github.com/akhilnasimk/SS_backend/internal/models"
+
+type SlugRedirectRepository interface {
+	FindRedirect(entityType, oldSlug string) (*models.SlugRedirect, error)
+}
@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// StockAlert is one line of the admin digest: a product at or below its threshold,
+// or one of its sizes sold out (Size set)
+type StockAlert struct {
+	ProductID  uuid.UUID
+	Name       string
+	Size       string
+	StockCount int
+	Threshold  int
+}
+
+type StockAlertRepository interface {
+	ClaimAlerts(productIDs []uuid.UUID, now time.Time) ([]StockAlert, error)
+	FindLowStock() ([]models.Product, error)
+}
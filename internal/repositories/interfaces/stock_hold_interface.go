@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/google/uuid"
+)
+
+type StockHoldRepository interface {
+	HoldCart(userID uuid.UUID, items []models.CartItem, expiresAt, now time.Time) ([]models.StockHold, error)
+	DeleteExpired(now time.Time) (int64, error)
+}
@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"github.com/akhilnasimk/SS_backend/internal/enums"
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/google/uuid"
+)
+
+// StockReconciliation compares a product's stock_count with the sum of its ledger
+type StockReconciliation struct {
+	ProductID   uuid.UUID
+	ProductName string
+	StockCount  int
+	LedgerStock int
+	Movements   int64
+}
+
+type StockMovementRepository interface {
+	FindByProduct(productID uuid.UUID, limit, offset int) ([]models.StockMovement, int64, error)
+	Adjust(productID uuid.UUID, variantID *uuid.UUID, delta int, reason enums.StockMovementReason, note string, actorID uuid.UUID) (*models.StockMovement, error)
+	Reconcile() ([]StockReconciliation, error)
+}
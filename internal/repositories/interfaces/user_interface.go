@@ -6,11 +6,13 @@ import (
 )
 
 type UserRepository interface {
-	CreateUser(user models.User) error
+	CreateUser(user *models.User) error
 	FindByEmail(email string) (*models.User, error)
 	FindByID(id uuid.UUID) (*models.User, error)
 	PatchPasswordByEmail(email string, hashedPassword string) error
 	PatchUser(id uuid.UUID, updates map[string]interface{}) error
 	GetAllUsersPaginated(limit, offset int) ([]models.User, int64, error)
+	GetUsersByCursor(limit int, after *Keyset) ([]models.User, error)
 	ToggleBlock(id uuid.UUID) error
+	FindAdminEmails() ([]string, error)
 }
@@ -5,9 +5,17 @@ import (
 	"github.com/google/uuid"
 )
 
+// PriceDropRecipient is a customer who wishlisted a product and still wants price-drop emails
+type PriceDropRecipient struct {
+	Email      string
+	UserName   string
+	PriceAtAdd int64
+}
+
 type WishlistRepository interface {
 	FindAllWishItems(id uuid.UUID) ([]models.Wishlist, error)
 	DeleteWishlist(id uuid.UUID) error
-	ToggleWishlist(userID, productID uuid.UUID) (string, *models.Wishlist, error)
+	ToggleWishlist(userID, productID uuid.UUID, price int64) (string, *models.Wishlist, error)
 	FindByUserAndProduct(userID, productID uuid.UUID) (*models.Wishlist, error)
+	ClaimPriceDropRecipients(productID uuid.UUID, price int64) ([]PriceDropRecipient, error)
 }
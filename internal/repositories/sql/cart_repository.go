@@ -3,6 +3,7 @@ package sql
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/akhilnasimk/SS_backend/internal/models"
 	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
@@ -22,14 +23,23 @@ func NewcartRepository(db gorm.DB) interfaces.CartRepository {
 }
 
 func (r *cartRepository) FindAllcartItemsOfUser(userID uuid.UUID) (models.Cart, error) {
+	return r.findCart("user_id", userID)
+}
+
+func (r *cartRepository) FindGuestCart(guestID uuid.UUID) (models.Cart, error) {
+	return r.findCart("guest_id", guestID)
+}
+
+func (r *cartRepository) findCart(column string, owner uuid.UUID) (models.Cart, error) {
 	var cart models.Cart
 
-	// Preload CartItems and Product for the given user
+	// Preload CartItems and Product for the given user (or guest)
 	err := r.DB.
 		Preload("CartItems").
 		Preload("CartItems.Product").
-		Preload("CartItems.Product.Images").
-		Where("user_id = ?", userID).
+		Preload("CartItems.Product.Images", orderedImages).
+		Preload("CartItems.Variant").
+		Where(column+" = ?", owner).
 		First(&cart).Error
 
 	if err != nil {
@@ -39,10 +49,21 @@ func (r *cartRepository) FindAllcartItemsOfUser(userID uuid.UUID) (models.Cart,
 	return cart, nil
 }
 
-func (r *cartRepository) AddItemToCart(userID uuid.UUID, productID uuid.UUID) (*models.CartItem, error) {
+func (r *cartRepository) AddItemToCart(owner models.Cart, productID uuid.UUID, variantID *uuid.UUID, limitSince time.Time) (*models.CartItem, error) {
     var resultCartItem models.CartItem
 
-    err := r.DB.Transaction(func(tx *gorm.DB) error {
+    column, ownerID, err := cartOwner(&owner)
+    if err != nil {
+        return nil, err
+    }
+
+    // guests hold no stock, so every hold belongs to someone else
+    holder := uuid.Nil
+    if owner.UserID != nil {
+        holder = *owner.UserID
+    }
+
+    err = r.DB.Transaction(func(tx *gorm.DB) error {
         // 1️⃣ Check product exists + active + stock available
         var product models.Product
         err := tx.
@@ -57,19 +78,52 @@ func (r *cartRepository) AddItemToCart(userID uuid.UUID, productID uuid.UUID) (*
             return err
         }
 
-        // 2️⃣ Check stock
+        // Timed drops can't be carted before release or after they end
+        if err := checkProductAvailable(&product, time.Now()); err != nil {
+            return err
+        }
+        if err := checkNoOpenRaffle(tx, &product); err != nil {
+            return err
+        }
+
+        // 2️⃣ Check stock (of the chosen size when the product has variants)
+        var variant *models.ProductVariant
+        if variantID != nil {
+            variant, err = lockVariant(tx, productID, *variantID)
+            if err != nil {
+                return err
+            }
+            if variant.StockCount <= 0 {
+                return fmt.Errorf("selected size is out of stock")
+            }
+        } else {
+            needsVariant, err := hasActiveVariants(tx, productID)
+            if err != nil {
+                return err
+            }
+            if needsVariant {
+                return fmt.Errorf("please select a size")
+            }
+        }
+
         if product.StockCount <= 0 {
             return fmt.Errorf("product out of stock")
         }
 
-        // 3️⃣ Check if user already has a cart
+        // Units on hold for other customers' checkouts aren't available either
+        if err := checkAvailableStock(tx, holder, &product, variant, 1, 1, time.Now()); err != nil {
+            return err
+        }
+
+        // 3️⃣ Check if user (or guest) already has a cart
         var cart models.Cart
-        err = tx.Where("user_id = ?", userID).First(&cart).Error
+        err = tx.Where(column+" = ?", ownerID).First(&cart).Error
 
         if errors.Is(err, gorm.ErrRecordNotFound) {
             cart = models.Cart{
-                ID:     uuid.New(),
-                UserID: userID,
+                ID:      uuid.New(),
+                UserID:  owner.UserID,
+                GuestID: owner.GuestID,
             }
 
             if err := tx.Create(&cart).Error; err != nil {
@@ -79,10 +133,15 @@ func (r *cartRepository) AddItemToCart(userID uuid.UUID, productID uuid.UUID) (*
             return err
         }
 
-        // 4️⃣ Check if cart already contains this product
+        // 4️⃣ Check if cart already contains this product (in this size)
         var cartItem models.CartItem
-        err = tx.Where("cart_id = ? AND product_id = ?", cart.ID, productID).
-            First(&cartItem).Error
+        itemQuery := tx.Where("cart_id = ? AND product_id = ?", cart.ID, productID)
+        if variantID != nil {
+            itemQuery = itemQuery.Where("variant_id = ?", *variantID)
+        } else {
+            itemQuery = itemQuery.Where("variant_id IS NULL")
+        }
+        err = itemQuery.First(&cartItem).Error
 
         if err == nil {
             // Item already exists - return error
@@ -93,11 +152,24 @@ func (r *cartRepository) AddItemToCart(userID uuid.UUID, productID uuid.UUID) (*
             return err
         }
 
+        // Purchase limit: past orders plus everything the cart will hold of this product.
+        // Guests have no order history, their cart is checked when it is ordered after login.
+        if owner.UserID != nil {
+            inCart, err := cartQuantity(tx, cart.ID, productID, nil)
+            if err != nil {
+                return err
+            }
+            if err := checkPurchaseLimit(tx, *owner.UserID, &product, variant, inCart+1, 1, limitSince); err != nil {
+                return err
+            }
+        }
+
         // 5️⃣ Add new item
         newCartItem := models.CartItem{
             ID:        uuid.New(),
             CartID:    cart.ID,
             ProductID: productID,
+            VariantID: variantID,
             Quantity:  1,
         }
 
@@ -105,8 +177,13 @@ func (r *cartRepository) AddItemToCart(userID uuid.UUID, productID uuid.UUID) (*
             return fmt.Errorf("failed creating cart item: %w", err)
         }
 
+        // Keeps guest carts from expiring while in use
+        if err := tx.Model(&cart).Update("updated_at", time.Now()).Error; err != nil {
+            return err
+        }
+
         // Load the product relation for response
-        if err := tx.Preload("Product.Images").First(&newCartItem, newCartItem.ID).Error; err != nil {
+        if err := tx.Preload("Product.Images", orderedImages).Preload("Variant").First(&newCartItem, newCartItem.ID).Error; err != nil {
             return err
         }
 
@@ -121,17 +198,40 @@ func (r *cartRepository) AddItemToCart(userID uuid.UUID, productID uuid.UUID) (*
     return &resultCartItem, nil
 }
 
-func (r *cartRepository) PatchQuantity(id uuid.UUID, op string) error {
+// PatchQuantity only touches items in the owner's cart
+func (r *cartRepository) PatchQuantity(owner models.Cart, id uuid.UUID, op string, limitSince time.Time) error {
+	ownedCart, err := r.ownedCart(&owner)
+	if err != nil {
+		return err
+	}
+
 	switch op {
 	case "inc":
-		return r.DB.Model(&models.CartItem{}).
-			Where("id = ?", id).
-			Update("quantity", gorm.Expr("quantity + 1")).Error
+		return r.DB.Transaction(func(tx *gorm.DB) error {
+			var item models.CartItem
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("id = ? AND cart_id IN (?)", id, ownedCart).
+				First(&item).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return fmt.Errorf("cart item not found")
+				}
+				return err
+			}
+
+			// Purchase limit on the new quantity
+			if err := checkCartItemLimit(tx, &item, limitSince); err != nil {
+				return err
+			}
+
+			return tx.Model(&models.CartItem{}).
+				Where("id = ?", id).
+				Update("quantity", gorm.Expr("quantity + 1")).Error
+		})
 
 	case "dec":
 		// Prevent quantity from going below 1
 		return r.DB.Model(&models.CartItem{}).
-			Where("id = ?", id).
+			Where("id = ? AND cart_id IN (?)", id, ownedCart).
 			Where("quantity > 1").
 			Update("quantity", gorm.Expr("quantity - 1")).Error
 
@@ -140,8 +240,192 @@ func (r *cartRepository) PatchQuantity(id uuid.UUID, op string) error {
 	}
 }
 
-func (r *cartRepository) HardDeleteCartItem(id uuid.UUID) error {
-	return r.DB.
-		Where("id = ?", id).
-		Delete(&models.CartItem{}).Error
+func (r *cartRepository) HardDeleteCartItem(owner models.Cart, id uuid.UUID) error {
+	ownedCart, err := r.ownedCart(&owner)
+	if err != nil {
+		return err
+	}
+
+	res := r.DB.
+		Where("id = ? AND cart_id IN (?)", id, ownedCart).
+		Delete(&models.CartItem{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("cart item not found")
+	}
+	return nil
+}
+
+// MergeGuestCart moves a guest's cart into the user's cart on login. Quantities of lines in
+// both carts are added up, and every line is capped at the stock left; lines that are no longer
+// for sale are dropped. The guest cart is deleted afterwards.
+func (r *cartRepository) MergeGuestCart(guestID, userID uuid.UUID) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var guestCart models.Cart
+		err := tx.Preload("CartItems").Where("guest_id = ?", guestID).First(&guestCart).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var userCart models.Cart
+		err = tx.Where("user_id = ?", userID).First(&userCart).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) && len(guestCart.CartItems) > 0 {
+			userCart = models.Cart{ID: uuid.New(), UserID: &userID}
+			err = tx.Create(&userCart).Error
+		}
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		for _, item := range guestCart.CartItems {
+			stock, err := mergeableStock(tx, item)
+			if err != nil {
+				return err
+			}
+			if stock <= 0 {
+				continue
+			}
+
+			var existing models.CartItem
+			query := tx.Where("cart_id = ? AND product_id = ?", userCart.ID, item.ProductID)
+			if item.VariantID != nil {
+				query = query.Where("variant_id = ?", *item.VariantID)
+			} else {
+				query = query.Where("variant_id IS NULL")
+			}
+			err = query.First(&existing).Error
+
+			switch {
+			case err == nil:
+				quantity := mergedQuantity(existing.Quantity, item.Quantity, stock)
+				if quantity > existing.Quantity {
+					if err := tx.Model(&existing).Update("quantity", quantity).Error; err != nil {
+						return err
+					}
+				}
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				if err := tx.Create(&models.CartItem{
+					ID:        uuid.New(),
+					CartID:    userCart.ID,
+					ProductID: item.ProductID,
+					VariantID: item.VariantID,
+					Quantity:  mergedQuantity(0, item.Quantity, stock),
+				}).Error; err != nil {
+					return err
+				}
+			default:
+				return err
+			}
+		}
+
+		if err := tx.Where("cart_id = ?", guestCart.ID).Delete(&models.CartItem{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&guestCart).Error
+	})
+}
+
+// DeleteStaleGuestCarts removes guest carts (and their items) untouched since before
+func (r *cartRepository) DeleteStaleGuestCarts(before time.Time) (int64, error) {
+	res := r.DB.Exec(`
+		WITH stale AS (
+			SELECT c.id FROM carts c
+			WHERE c.guest_id IS NOT NULL AND c.updated_at < ?
+			  AND NOT EXISTS (SELECT 1 FROM cart_items i WHERE i.cart_id = c.id AND i.updated_at >= ?)
+		), items AS (
+			DELETE FROM cart_items WHERE cart_id IN (SELECT id FROM stale)
+		)
+		DELETE FROM carts WHERE id IN (SELECT id FROM stale)`,
+		before, before)
+	return res.RowsAffected, res.Error
+}
+
+// ownedCart is a subquery for the id of the owner's cart
+func (r *cartRepository) ownedCart(owner *models.Cart) (*gorm.DB, error) {
+	column, ownerID, err := cartOwner(owner)
+	if err != nil {
+		return nil, err
+	}
+	return r.DB.Model(&models.Cart{}).Select("id").Where(column+" = ?", ownerID), nil
+}
+
+// cartOwner picks the owner column of a cart: user when logged in, guest otherwise
+func cartOwner(owner *models.Cart) (string, uuid.UUID, error) {
+	switch {
+	case owner.UserID != nil:
+		return "user_id", *owner.UserID, nil
+	case owner.GuestID != nil:
+		return "guest_id", *owner.GuestID, nil
+	default:
+		return "", uuid.Nil, errors.New("cart has no user or guest")
+	}
+}
+
+// mergeableStock is how many units of a guest cart line can still be sold: the size's stock for
+// sized lines, else the product's; 0 once the product or size is gone or switched off
+func mergeableStock(tx *gorm.DB, item models.CartItem) (int, error) {
+	var product models.Product
+	err := tx.Where("id = ? AND is_active = TRUE", item.ProductID).First(&product).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if item.VariantID == nil {
+		return product.StockCount, nil
+	}
+
+	var variant models.ProductVariant
+	err = tx.Where("id = ? AND product_id = ? AND is_active = TRUE", *item.VariantID, item.ProductID).First(&variant).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return min(variant.StockCount, product.StockCount), nil
+}
+
+// mergedQuantity is a cart line's quantity after adding the guest's units to the account's:
+// capped at the stock, but an account line already above it is never lowered
+func mergedQuantity(existing, guest, stock int) int {
+	return max(existing, min(existing+guest, stock))
+}
+
+// checkCartItemLimit checks max_per_customer for a cart line about to grow by one unit
+func checkCartItemLimit(tx *gorm.DB, item *models.CartItem, limitSince time.Time) error {
+	var cart models.Cart
+	if err := tx.Where("id = ?", item.CartID).First(&cart).Error; err != nil {
+		return err
+	}
+	// guests are checked once their cart is ordered after login
+	if cart.UserID == nil {
+		return nil
+	}
+
+	var product models.Product
+	if err := tx.Where("id = ?", item.ProductID).First(&product).Error; err != nil {
+		return err
+	}
+
+	var variant *models.ProductVariant
+	if item.VariantID != nil {
+		variant = &models.ProductVariant{}
+		if err := tx.Where("id = ?", *item.VariantID).First(variant).Error; err != nil {
+			return err
+		}
+	}
+
+	inCart, err := cartQuantity(tx, item.CartID, item.ProductID, nil)
+	if err != nil {
+		return err
+	}
+
+	return checkPurchaseLimit(tx, *cart.UserID, &product, variant, inCart+1, item.Quantity+1, limitSince)
 }
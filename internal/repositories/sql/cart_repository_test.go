@@ -0,0 +1,26 @@
+package sql
+
+import "testing"
+
+func TestMergedQuantity(t *testing.T) {
+	tests := []struct {
+		name                   string
+		existing, guest, stock int
+		want                   int
+	}{
+		{name: "new line within stock", existing: 0, guest: 2, stock: 5, want: 2},
+		{name: "new line capped at stock", existing: 0, guest: 4, stock: 3, want: 3},
+		{name: "adds to the account line", existing: 1, guest: 2, stock: 5, want: 3},
+		{name: "sum capped at stock", existing: 2, guest: 2, stock: 3, want: 3},
+		{name: "account line already at stock", existing: 3, guest: 1, stock: 3, want: 3},
+		{name: "account line above stock is not lowered", existing: 4, guest: 1, stock: 2, want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mergedQuantity(tt.existing, tt.guest, tt.stock); got != tt.want {
+				t.Fatalf("mergedQuantity(%d, %d, %d) = %d, want %d", tt.existing, tt.guest, tt.stock, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package sql
+
+import (
+	"errors"
+	"fmt"
+
+	constent "github.com/akhilnasimk/SS_backend/internal/const"
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// categorySubtreeSQL selects a category and every category below it
+const categorySubtreeSQL = `WITH RECURSIVE subtree AS (
+	SELECT id FROM categories WHERE id = ?
+	UNION ALL
+	SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+) SELECT id FROM subtree`
+
+type categoryRepository struct {
+	DB *gorm.DB
+}
+
+func NewCategoryRepository(db *gorm.DB) interfaces.CategoryRepository {
+	return &categoryRepository{
+		DB: db,
+	}
+}
+
+// FindAll returns every category in display order, the service builds the tree
+func (r *categoryRepository) FindAll() ([]models.Category, error) {
+	var categories []models.Category
+	err := r.DB.Order("display_order ASC, name ASC").Find(&categories).Error
+	return categories, err
+}
+
+func (r *categoryRepository) FindByID(id uuid.UUID) (*models.Category, error) {
+	var category models.Category
+	err := r.DB.Where("id = ?", id).First(&category).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("category not found")
+		}
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (r *categoryRepository) FindBySlug(slug string) (*models.Category, error) {
+	var category models.Category
+	err := r.DB.Where("slug = ?", slug).First(&category).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("category not found")
+		}
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (r *categoryRepository) SlugExists(slug string, excludeID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.DB.Model(&models.Category{}).
+		Where("slug = ? AND id <> ?", slug, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// NameExists checks for a sibling with the same name (top level siblings share parent NULL)
+func (r *categoryRepository) NameExists(name string, parentID *uuid.UUID, excludeID uuid.UUID) (bool, error) {
+	var count int64
+	db := r.DB.Model(&models.Category{}).Where("LOWER(name) = LOWER(?) AND id <> ?", name, excludeID)
+	if parentID == nil {
+		db = db.Where("parent_id IS NULL")
+	} else {
+		db = db.Where("parent_id = ?", *parentID)
+	}
+	err := db.Count(&count).Error
+	return count > 0, err
+}
+
+// IsDescendant tells if categoryID sits somewhere in ancestorID's subtree (itself included)
+func (r *categoryRepository) IsDescendant(categoryID, ancestorID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.DB.Raw("SELECT COUNT(*) FROM ("+categorySubtreeSQL+") t WHERE t.id = ?", ancestorID, categoryID).
+		Scan(&count).Error
+	return count > 0, err
+}
+
+// CountProducts counts products pointing at the category, soft-deleted ones too (they still hold the FK)
+func (r *categoryRepository) CountProducts(id uuid.UUID) (int64, error) {
+	var count int64
+	err := r.DB.Unscoped().Model(&models.Product{}).Where("category_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+func (r *categoryRepository) Create(category *models.Category) error {
+	return r.DB.Create(category).Error
+}
+
+func (r *categoryRepository) Update(category *models.Category) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var oldSlug string
+		if err := tx.Model(&models.Category{}).Where("id = ?", category.ID).Pluck("slug", &oldSlug).Error; err != nil {
+			return err
+		}
+		if err := recordSlugChange(tx, constent.SlugEntityCategory, category.ID, oldSlug, category.Slug); err != nil {
+			return err
+		}
+
+		return tx.Model(&models.Category{}).
+			Where("id = ?", category.ID).
+			Updates(map[string]interface{}{
+				"name":          category.Name,
+				"slug":          category.Slug,
+				"parent_id":     category.ParentID,
+				"display_order": category.DisplayOrder,
+			}).Error
+	})
+}
+
+// Delete removes a category. Its children move up to its parent; its products must be
+// reassigned (reassignTo) or the delete is refused.
+func (r *categoryRepository) Delete(id uuid.UUID, reassignTo *uuid.UUID) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var category models.Category
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&category).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("category not found")
+			}
+			return err
+		}
+
+		var products int64
+		if err := tx.Unscoped().Model(&models.Product{}).Where("category_id = ?", id).Count(&products).Error; err != nil {
+			return err
+		}
+
+		if products > 0 {
+			if reassignTo == nil {
+				return fmt.Errorf("category still has %d products, reassign them first", products)
+			}
+
+			if err := tx.Unscoped().Model(&models.Product{}).
+				Where("category_id = ?", id).
+				Update("category_id", *reassignTo).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&models.Category{}).
+			Where("parent_id = ?", id).
+			Update("parent_id", category.ParentID).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&category).Error
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"time"
 
+	constent "github.com/akhilnasimk/SS_backend/internal/const"
+	"github.com/akhilnasimk/SS_backend/internal/enums"
 	"github.com/akhilnasimk/SS_backend/internal/models"
 	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
 	"github.com/google/uuid"
@@ -32,9 +34,7 @@ func (R *orderRepository) FindAllOrders(userID uuid.UUID) ([]models.Order, error
 		Preload("OrderItems.Product", func(db *gorm.DB) *gorm.DB {
 			return db.Where("deleted_at IS NULL AND is_active = ?", true)
 		}).
-		Preload("OrderItems.Product.Images", func(db *gorm.DB) *gorm.DB {
-			return db.Where("deleted_at IS NULL").Order("priority ASC")
-		}).
+		Preload("OrderItems.Product.Images", orderedImages).
 		Where("user_id = ?", userID).
 		Order("created_at DESC").
 		Find(&orders).Error
@@ -51,13 +51,41 @@ func (R *orderRepository) FindAllOrders(userID uuid.UUID) ([]models.Order, error
 	return orders, nil
 }
 
+// FindOrdersByCursor pages a user's order history by (created_at, id)
+func (R *orderRepository) FindOrdersByCursor(userID uuid.UUID, limit int, after *interfaces.Keyset) ([]models.Order, error) {
+	var orders []models.Order
+
+	query := R.DB.
+		Preload("OrderItems", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Preload("OrderItems.Product", func(db *gorm.DB) *gorm.DB {
+			return db.Where("deleted_at IS NULL AND is_active = ?", true)
+		}).
+		Preload("OrderItems.Product.Images", orderedImages).
+		Where("user_id = ?", userID)
+
+	if after != nil {
+		query = query.Where("(created_at, id) < (?, ?)", after.CreatedAt, after.ID)
+	}
+
+	if err := query.
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&orders).Error; err != nil {
+		return []models.Order{}, err
+	}
+
+	return orders, nil
+}
+
 // methode for both single and cart ordering
 func (r *orderRepository) CreateOrder(order *models.Order) error {
 	return r.DB.Create(&order).Error
 }
 
 // ordering an entire cart
-func (r *orderRepository) CreateOrderWithItems(order *models.Order, items []models.CartItem) error {
+func (r *orderRepository) CreateOrderWithItems(order *models.Order, items []models.CartItem, limitSince time.Time) error {
 	tx := r.DB.Begin()
 	if tx.Error != nil {
 		return tx.Error
@@ -69,23 +97,71 @@ func (r *orderRepository) CreateOrderWithItems(order *models.Order, items []mode
 		return err
 	}
 
+	// Purchase limits are checked for the whole cart before any line is written: the lines of
+	// this order would otherwise count as already ordered on top of the cart totals
+	productQty, variantQty := cartPurchaseTotals(items)
+	for _, ci := range items {
+		var product models.Product
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", ci.ProductID).
+			First(&product).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		variant, err := resolveOrderVariant(tx, &product, ci.VariantID)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		var sizeQty int
+		if ci.VariantID != nil {
+			sizeQty = variantQty[*ci.VariantID]
+		}
+		if err := checkPurchaseLimit(tx, order.UserID, &product, variant, productQty[ci.ProductID], sizeQty, limitSince); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	now := time.Now()
+	var total float64
 	for _, ci := range items {
 		// Fetch product with images
 		var product models.Product
 		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
-			Preload("Images", func(db *gorm.DB) *gorm.DB {
-				return db.Order("priority ASC").Limit(1) // Get primary image
-			}).
+			Preload("Images", primaryImage). // Get primary image
 			Where("id = ?", ci.ProductID).
 			First(&product).Error; err != nil {
 			tx.Rollback()
 			return err
 		}
+		if err := checkProductAvailable(&product, now); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if err := checkNoOpenRaffle(tx, &product); err != nil {
+			tx.Rollback()
+			return err
+		}
 
-		// Check stock
-		if product.StockCount < ci.Quantity {
+		// Resolve the variant (size) and its price
+		variant, err := resolveOrderVariant(tx, &product, ci.VariantID)
+		if err != nil {
 			tx.Rollback()
-			return fmt.Errorf("insufficient stock for product %s", product.Name)
+			return err
+		}
+
+		price := product.Price
+		if variant != nil {
+			price = variant.EffectivePrice(product.Price)
+		}
+
+		// Check stock, minus what other customers hold at checkout
+		if err := checkAvailableStock(tx, order.UserID, &product, variant, ci.Quantity, ci.Quantity, now); err != nil {
+			tx.Rollback()
+			return err
 		}
 
 		// Decrease stock
@@ -94,28 +170,54 @@ func (r *orderRepository) CreateOrderWithItems(order *models.Order, items []mode
 			tx.Rollback()
 			return err
 		}
+		if variant != nil {
+			if err := adjustVariantStock(tx, variant.ID, -ci.Quantity); err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
 
 		// **CAPTURE PRODUCT SNAPSHOT**
 		var productImage string
 		if len(product.Images) > 0 {
-			productImage = product.Images[0].URL // or ImageURL
+			productImage = product.Images[0].SizedURL(constent.ImageSizeThumbnail)
 		}
 
 		// Create order item with snapshot
 		orderItem := models.OrderItem{
 			OrderID:      order.ID,
 			ProductID:    ci.ProductID,
+			VariantID:    ci.VariantID,
 			ProductName:  product.Name,
 			ProductImage: productImage,
 			Quantity:     ci.Quantity,
-			Price:        float64(product.Price),
-			TotalPrice:   float64(ci.Quantity) * float64(product.Price),
+			Price:        float64(price),
+			TotalPrice:   float64(ci.Quantity) * float64(price),
 		}
+		snapshotVariant(&orderItem, variant)
 
 		if err := tx.Create(&orderItem).Error; err != nil {
 			tx.Rollback()
 			return err
 		}
+		if err := recordStockMovement(tx, saleMovement(order, &orderItem)); err != nil {
+			tx.Rollback()
+			return err
+		}
+		total += orderItem.TotalPrice
+	}
+
+	// Total from the locked prices (variant overrides included)
+	order.TotalAmount = total
+	if err := tx.Model(order).Update("total_amount", total).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// The checkout holds became the sale
+	if err := tx.Where("user_id = ?", order.UserID).Delete(&models.StockHold{}).Error; err != nil {
+		tx.Rollback()
+		return err
 	}
 
 	// Delete cart items
@@ -133,7 +235,7 @@ func (r *orderRepository) CreateOrderWithItems(order *models.Order, items []mode
 }
 
 // ordering a single item
-func (r *orderRepository) CreateSingleOrder(order *models.Order, productID uuid.UUID, quantity int) error {
+func (r *orderRepository) CreateSingleOrder(order *models.Order, productID uuid.UUID, variantID *uuid.UUID, quantity int, limitSince time.Time) error {
 	tx := r.DB.Begin()
 	if tx.Error != nil {
 		return tx.Error
@@ -142,23 +244,47 @@ func (r *orderRepository) CreateSingleOrder(order *models.Order, productID uuid.
 	// Fetch Product with images and lock
 	var product models.Product
 	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
-		Preload("Images", func(db *gorm.DB) *gorm.DB {
-			return db.Order("priority ASC").Limit(1)
-		}).
+		Preload("Images", primaryImage).
 		Where("id = ?", productID).
 		First(&product).Error; err != nil {
 		tx.Rollback()
 		return err
 	}
+	if err := checkProductAvailable(&product, time.Now()); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := checkNoOpenRaffle(tx, &product); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Resolve the variant (size) and its price
+	variant, err := resolveOrderVariant(tx, &product, variantID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
 
-	// Stock check
-	if product.StockCount < quantity {
+	price := product.Price
+	if variant != nil {
+		price = variant.EffectivePrice(product.Price)
+	}
+
+	// Stock check, minus what other customers hold at checkout
+	if err := checkAvailableStock(tx, order.UserID, &product, variant, quantity, quantity, time.Now()); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Purchase limit check
+	if err := checkPurchaseLimit(tx, order.UserID, &product, variant, quantity, quantity, limitSince); err != nil {
 		tx.Rollback()
-		return fmt.Errorf("insufficient stock for product %s", product.Name)
+		return err
 	}
 
 	// **CALCULATE TOTAL**
-	totalAmount := float64(quantity) * float64(product.Price)
+	totalAmount := float64(quantity) * float64(price)
 	order.TotalAmount = totalAmount // ✅ SET THE TOTAL
 
 	// Create Order
@@ -170,19 +296,21 @@ func (r *orderRepository) CreateSingleOrder(order *models.Order, productID uuid.
 	// Capture product snapshot
 	var productImage string
 	if len(product.Images) > 0 {
-		productImage = product.Images[0].URL
+		productImage = product.Images[0].SizedURL(constent.ImageSizeThumbnail)
 	}
 
 	// Create Order Item with snapshot
 	orderItem := models.OrderItem{
 		OrderID:      order.ID,
 		ProductID:    productID,
+		VariantID:    variantID,
 		ProductName:  product.Name,
 		ProductImage: productImage,
 		Quantity:     quantity,
-		Price:        float64(product.Price),
+		Price:        float64(price),
 		TotalPrice:   totalAmount, // Same as order total for single item
 	}
+	snapshotVariant(&orderItem, variant)
 
 	if err := tx.Create(&orderItem).Error; err != nil {
 		tx.Rollback()
@@ -195,11 +323,73 @@ func (r *orderRepository) CreateSingleOrder(order *models.Order, productID uuid.
 		tx.Rollback()
 		return err
 	}
+	if variant != nil {
+		if err := adjustVariantStock(tx, variant.ID, -quantity); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if err := recordStockMovement(tx, saleMovement(order, &orderItem)); err != nil {
+		tx.Rollback()
+		return err
+	}
 
 	return tx.Commit().Error
 }
 
-func (r *orderRepository) CancelSingleOrderItem(orderItemID uuid.UUID) error {
+// resolveOrderVariant locks the chosen variant, or fails if the product needs one
+func resolveOrderVariant(tx *gorm.DB, product *models.Product, variantID *uuid.UUID) (*models.ProductVariant, error) {
+	if variantID == nil {
+		needsVariant, err := hasActiveVariants(tx, product.ID)
+		if err != nil {
+			return nil, err
+		}
+		if needsVariant {
+			return nil, fmt.Errorf("please select a size for product %s", product.Name)
+		}
+		return nil, nil
+	}
+
+	return lockVariant(tx, product.ID, *variantID)
+}
+
+// saleMovement is the ledger line for the units an order line took from stock
+func saleMovement(order *models.Order, item *models.OrderItem) *models.StockMovement {
+	return &models.StockMovement{
+		ProductID:     item.ProductID,
+		VariantID:     item.VariantID,
+		Delta:         -item.Quantity,
+		Reason:        enums.MovementSale,
+		ReferenceType: constent.StockRefOrderItem,
+		ReferenceID:   &item.ID,
+		ActorID:       &order.UserID,
+	}
+}
+
+// cancellationMovement is the ledger line for the units a cancelled order line put back
+func cancellationMovement(item *models.OrderItem, actorID uuid.UUID) *models.StockMovement {
+	return &models.StockMovement{
+		ProductID:     item.ProductID,
+		VariantID:     item.VariantID,
+		Delta:         item.Quantity,
+		Reason:        enums.MovementCancellation,
+		ReferenceType: constent.StockRefOrderItem,
+		ReferenceID:   &item.ID,
+		ActorID:       optionalActor(actorID),
+	}
+}
+
+// snapshotVariant copies the size details onto the order line
+func snapshotVariant(item *models.OrderItem, variant *models.ProductVariant) {
+	if variant == nil {
+		return
+	}
+	item.Size = variant.Size
+	item.Colorway = variant.Colorway
+	item.SKU = variant.SKU
+}
+
+func (r *orderRepository) CancelSingleOrderItem(orderItemID uuid.UUID, actorID uuid.UUID) error {
 	tx := r.DB.Begin()
 	if tx.Error != nil {
 		return tx.Error
@@ -242,6 +432,16 @@ func (r *orderRepository) CancelSingleOrderItem(orderItemID uuid.UUID) error {
 		tx.Rollback()
 		return err
 	}
+	if item.VariantID != nil {
+		if err := adjustVariantStock(tx, *item.VariantID, item.Quantity); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	if err := recordStockMovement(tx, cancellationMovement(&item, actorID)); err != nil {
+		tx.Rollback()
+		return err
+	}
 
 	// 4. If all other items also cancelled → cancel whole order
 	var activeCount int64
@@ -262,7 +462,7 @@ func (r *orderRepository) CancelSingleOrderItem(orderItemID uuid.UUID) error {
 	return tx.Commit().Error
 }
 
-func (r *orderRepository) CancelWholeOrder(orderID uuid.UUID) error {
+func (r *orderRepository) CancelWholeOrder(orderID uuid.UUID, actorID uuid.UUID) error {
 	tx := r.DB.Begin()
 	if tx.Error != nil {
 		return tx.Error
@@ -301,6 +501,16 @@ func (r *orderRepository) CancelWholeOrder(orderID uuid.UUID) error {
 			tx.Rollback()
 			return err
 		}
+		if item.VariantID != nil {
+			if err := adjustVariantStock(tx, *item.VariantID, item.Quantity); err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+		if err := recordStockMovement(tx, cancellationMovement(&item, actorID)); err != nil {
+			tx.Rollback()
+			return err
+		}
 	}
 
 	// 3. Cancel ALL order items
@@ -388,3 +598,16 @@ func (r *orderRepository) UpdateOrderStatus(orderID uuid.UUID, newStatus string)
 
 	return tx.Commit().Error
 }
+
+// HasDeliveredPurchase tells if the user received the product in a delivered, non-cancelled order line
+func (r *orderRepository) HasDeliveredPurchase(userID, productID uuid.UUID) (bool, error) {
+	var count int64
+
+	err := r.DB.Model(&models.OrderItem{}).
+		Joins("JOIN orders ON orders.id = order_items.order_id").
+		Where("orders.user_id = ? AND order_items.product_id = ?", userID, productID).
+		Where("orders.status = ? AND orders.cancelled_at IS NULL AND order_items.cancelled_at IS NULL", "delivered").
+		Count(&count).Error
+
+	return count > 0, err
+}
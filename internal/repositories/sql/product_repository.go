@@ -1,12 +1,18 @@
 package sql
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
+	constent "github.com/akhilnasimk/SS_backend/internal/const"
+	"github.com/akhilnasimk/SS_backend/internal/enums"
+	"github.com/akhilnasimk/SS_backend/internal/helpers"
 	"github.com/akhilnasimk/SS_backend/internal/models"
 	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type productsRepository struct {
@@ -19,10 +25,91 @@ func NewProductsRepository(db gorm.DB) interfaces.ProductsRepository {
 	}
 }
 
-func (r *productsRepository) GetAllProducts(limit int, offset int, categoryID string, search string, minPrice int64, maxPrice int64, includeDeleted bool) ([]models.Product, int64, error) {
+func (r *productsRepository) GetAllProducts(limit int, offset int, categoryID string, search string, minPrice int64, maxPrice int64, includeDeleted bool, sort enums.ProductSort) ([]models.Product, int64, error) {
 	var products []models.Product
 	var total int64
 
+	db, tsQuery := r.filteredProducts(categoryID, search, minPrice, maxPrice, includeDeleted)
+
+	// Count total with filters
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	db = applyProductSort(db, sort, tsQuery)
+
+	// Fetch results
+	result := db.
+		Preload("Images", orderedImages).
+		Preload("Category").
+		Limit(limit).
+		Offset(offset).
+		Find(&products)
+
+	return products, total, result.Error
+}
+
+// units sold per product, ignoring cancelled lines and cancelled orders
+const productSalesJoin = `LEFT JOIN (
+	SELECT oi.product_id, SUM(oi.quantity) AS units_sold
+	FROM order_items oi
+	JOIN orders o ON o.id = oi.order_id
+	WHERE oi.cancelled_at IS NULL AND o.status <> 'cancelled'
+	GROUP BY oi.product_id
+) sales ON sales.product_id = products.id`
+
+// applyProductSort orders the listing; every ordering ends with products.id so pages are deterministic
+func applyProductSort(db *gorm.DB, sort enums.ProductSort, tsQuery string) *gorm.DB {
+	switch sort {
+	case enums.SortPriceAsc:
+		return db.Order("products.price ASC, products.id ASC")
+	case enums.SortPriceDesc:
+		return db.Order("products.price DESC, products.id DESC")
+	case enums.SortName:
+		return db.Order("LOWER(products.name) ASC, products.id ASC")
+	case enums.SortTopRated:
+		return db.Order("products.average_rating DESC, products.review_count DESC, products.id DESC")
+	case enums.SortBestSelling:
+		return db.Select("products.*").
+			Joins(productSalesJoin).
+			Order("COALESCE(sales.units_sold, 0) DESC, products.created_at DESC, products.id DESC")
+	case enums.SortRelevance:
+		// Most relevant first when searching, newest first otherwise
+		if tsQuery != "" {
+			db = db.Order(clause.OrderBy{Expression: clause.Expr{
+				SQL:                "ts_rank(products.search_vector, to_tsquery('simple', ?)) DESC",
+				Vars:               []interface{}{tsQuery},
+				WithoutParentheses: true,
+			}})
+		}
+	}
+
+	return db.Order("products.created_at DESC, products.id DESC")
+}
+
+// GetProductsByCursor pages the listing by (created_at, id) instead of OFFSET, no COUNT(*)
+func (r *productsRepository) GetProductsByCursor(limit int, after *interfaces.Keyset, categoryID string, search string, minPrice int64, maxPrice int64, includeDeleted bool) ([]models.Product, error) {
+	var products []models.Product
+
+	db, _ := r.filteredProducts(categoryID, search, minPrice, maxPrice, includeDeleted)
+
+	if after != nil {
+		db = db.Where("(products.created_at, products.id) < (?, ?)", after.CreatedAt, after.ID)
+	}
+
+	err := db.
+		Preload("Images", orderedImages).
+		Preload("Category").
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&products).Error
+
+	return products, err
+}
+
+// filteredProducts builds the listing query (visibility + filters).
+// The listing and its facet counts both use it so they always agree.
+func (r *productsRepository) filteredProducts(categoryID string, search string, minPrice int64, maxPrice int64, includeDeleted bool) (*gorm.DB, string) {
 	db := r.DB.Model(&models.Product{})
 
 	// Show deleted products for admin
@@ -30,17 +117,23 @@ func (r *productsRepository) GetAllProducts(limit int, offset int, categoryID st
 		db = db.Unscoped() // Include soft-deleted records
 	}
 
-	// Filter by active status (non-admin only)
+	// Filter by active status and drop window (non-admin only)
 	if !includeDeleted {
-		db = db.Where("is_active = ?", true)
+		db = db.Scopes(availableProducts(time.Now()))
 	}
 
 	// Apply filters
 	if categoryID != "" {
-		db = db.Where("category_id = ?", categoryID)
+		// a parent category also lists everything filed under its sub-categories
+		db = db.Where("products.category_id IN ("+categorySubtreeSQL+")", categoryID)
 	}
 
-	if search != "" {
+	// Full-text search (name, description, category) with prefix matching
+	tsQuery := helpers.BuildPrefixTSQuery(search)
+	if tsQuery != "" {
+		db = db.Where("search_vector @@ to_tsquery('simple', ?)", tsQuery)
+	} else if search != "" {
+		// nothing indexable in the term (only symbols), keep the old substring match
 		db = db.Where("LOWER(name) LIKE LOWER(?)", "%"+search+"%")
 	}
 
@@ -52,32 +145,122 @@ func (r *productsRepository) GetAllProducts(limit int, offset int, categoryID st
 		db = db.Where("price <= ?", maxPrice)
 	}
 
-	// Count total with filters
-	if err := db.Count(&total).Error; err != nil {
-		return nil, 0, err
+	return db, tsQuery
+}
+
+// GetProductFacets counts the filtered products per category, price bucket and availability
+func (r *productsRepository) GetProductFacets(categoryID string, search string, minPrice int64, maxPrice int64, includeDeleted bool) (interfaces.ProductFacetCounts, error) {
+	facets := interfaces.ProductFacetCounts{}
+
+	// 1. Categories
+	var categoryRows []struct {
+		CategoryID uuid.UUID
+		Count      int64
+	}
+	db, _ := r.filteredProducts(categoryID, search, minPrice, maxPrice, includeDeleted)
+	if err := db.Select("category_id, COUNT(*) AS count").
+		Group("category_id").
+		Order("count DESC").
+		Scan(&categoryRows).Error; err != nil {
+		return facets, fmt.Errorf("failed to count categories: %w", err)
 	}
 
-	// Fetch results
-	result := db.
-		Preload("Images").
-		Preload("Category").
-		Limit(limit).
-		Offset(offset).
-		Order("created_at DESC").
-		Find(&products)
+	ids := make([]uuid.UUID, len(categoryRows))
+	for i, row := range categoryRows {
+		ids[i] = row.CategoryID
+	}
+	names := make(map[uuid.UUID]string, len(ids))
+	if len(ids) > 0 {
+		var categories []models.Category
+		if err := r.DB.Where("id IN ?", ids).Find(&categories).Error; err != nil {
+			return facets, err
+		}
+		for _, c := range categories {
+			names[c.ID] = c.Name
+		}
+	}
+	for _, row := range categoryRows {
+		facets.Categories = append(facets.Categories, interfaces.FacetCount{
+			Key:   row.CategoryID.String(),
+			Label: names[row.CategoryID],
+			Count: row.Count,
+		})
+	}
 
-	return products, total, result.Error
+	// 2. Price buckets
+	bucketCase := "CASE"
+	for _, b := range constent.PriceBuckets {
+		if b.Max > 0 {
+			bucketCase += fmt.Sprintf(" WHEN price >= %d AND price < %d THEN '%s'", b.Min, b.Max, b.Key)
+		} else {
+			bucketCase += fmt.Sprintf(" WHEN price >= %d THEN '%s'", b.Min, b.Key)
+		}
+	}
+	bucketCase += " END"
+
+	var bucketRows []struct {
+		Bucket string
+		Count  int64
+	}
+	db, _ = r.filteredProducts(categoryID, search, minPrice, maxPrice, includeDeleted)
+	if err := db.Select(bucketCase + " AS bucket, COUNT(*) AS count").
+		Group("bucket").
+		Scan(&bucketRows).Error; err != nil {
+		return facets, fmt.Errorf("failed to count price buckets: %w", err)
+	}
+
+	bucketCounts := make(map[string]int64, len(bucketRows))
+	for _, row := range bucketRows {
+		bucketCounts[row.Bucket] = row.Count
+	}
+	for _, b := range constent.PriceBuckets { // keep the configured order, zero counts included
+		facets.PriceBuckets = append(facets.PriceBuckets, interfaces.FacetCount{
+			Key:   b.Key,
+			Label: b.Label,
+			Count: bucketCounts[b.Key],
+		})
+	}
+
+	// 3. Availability
+	var stock struct {
+		InStock    int64
+		OutOfStock int64
+	}
+	db, _ = r.filteredProducts(categoryID, search, minPrice, maxPrice, includeDeleted)
+	if err := db.Select(
+		"COUNT(*) FILTER (WHERE stock_count > 0) AS in_stock, " +
+			"COUNT(*) FILTER (WHERE stock_count <= 0) AS out_of_stock",
+	).Scan(&stock).Error; err != nil {
+		return facets, fmt.Errorf("failed to count availability: %w", err)
+	}
+	facets.Availability = []interfaces.FacetCount{
+		{Key: "in_stock", Label: "In stock", Count: stock.InStock},
+		{Key: "out_of_stock", Label: "Out of stock", Count: stock.OutOfStock},
+	}
+
+	return facets, nil
 }
 
 func (R *productsRepository) ProductById(id uuid.UUID) (models.Product, error) {
+	return R.productDetail("id = ?", id)
+}
+
+// ProductBySlug loads the product page by its current slug
+func (R *productsRepository) ProductBySlug(slug string) (models.Product, error) {
+	return R.productDetail("slug = ?", slug)
+}
+
+// productDetail loads a product with everything the product page shows
+func (R *productsRepository) productDetail(query string, arg interface{}) (models.Product, error) {
 	var product models.Product
 
 	err := R.DB.
-		Preload("Images", func(db *gorm.DB) *gorm.DB {
-			return db.Order("created_at ASC")
+		Preload("Images", orderedImages).
+		Preload("Variants", func(db *gorm.DB) *gorm.DB {
+			return db.Where("is_active = ?", true).Order("created_at ASC")
 		}).
 		Preload("Category").
-		First(&product, "id = ?", id).Error
+		First(&product, query, arg).Error
 
 	if err != nil {
 		return models.Product{}, err
@@ -86,17 +269,59 @@ func (R *productsRepository) ProductById(id uuid.UUID) (models.Product, error) {
 	return product, nil
 }
 
+// FindUpcoming lists active products whose release is still ahead, soonest first
+func (r *productsRepository) FindUpcoming(now time.Time, limit int) ([]models.Product, error) {
+	var products []models.Product
+
+	err := r.DB.
+		Preload("Images", orderedImages).
+		Preload("Category").
+		Where("is_active = ? AND release_at > ?", true, now).
+		Order("release_at ASC, id ASC").
+		Limit(limit).
+		Find(&products).Error
+
+	return products, err
+}
+
+// HeldStock sums the active checkout holds of the given products
+func (r *productsRepository) HeldStock(productIDs []uuid.UUID, now time.Time) (map[uuid.UUID]int, map[uuid.UUID]int, error) {
+	return activeHolds(&r.DB, productIDs, now)
+}
+
+// SlugExists also counts soft-deleted products, they get their slug back on restore
+func (r *productsRepository) SlugExists(slug string, excludeID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.DB.Unscoped().Model(&models.Product{}).
+		Where("slug = ? AND id <> ?", slug, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
 //admin prodduct managin
 
 // products_repository.go
 
-func (r *productsRepository) CreateProductWithImages(product models.Product, images []models.ProductImage) (models.Product, error) {
+func (r *productsRepository) CreateProductWithImages(product models.Product, images []models.ProductImage, actorID uuid.UUID) (models.Product, error) {
 	err := r.DB.Transaction(func(tx *gorm.DB) error {
 		// Create product
 		if err := tx.Create(&product).Error; err != nil {
 			return err
 		}
 
+		// opening stock is the first line of the ledger
+		if err := recordStockMovement(tx, &models.StockMovement{
+			ProductID:     product.ID,
+			Delta:         product.StockCount,
+			Reason:        enums.MovementAdminAdjustment,
+			ReferenceType: constent.StockRefProduct,
+			ReferenceID:   &product.ID,
+			ActorID:       optionalActor(actorID),
+			Note:          "initial stock",
+		}); err != nil {
+			return err
+		}
+
 		// Batch insert images in one query
 		if len(images) > 0 {
 			for i := range images {
@@ -122,7 +347,7 @@ func (r *productsRepository) CreateProductWithImages(product models.Product, ima
 // catogories to map on the front end
 func (r *productsRepository) FindAllCategory() ([]models.Category, error) {
 	var categories []models.Category
-	resp := r.DB.Find(&categories)
+	resp := r.DB.Order("display_order ASC, name ASC").Find(&categories)
 	if resp.Error != nil {
 		return categories, resp.Error
 	}
@@ -133,7 +358,7 @@ func (r *productsRepository) FindAllCategory() ([]models.Category, error) {
 func (r *productsRepository) FindById(id uuid.UUID) (*models.Product, error) {
 	var product models.Product
 	// Preload existing images
-	err := r.DB.Preload("Images").Where("id = ?", id).First(&product).Error
+	err := r.DB.Preload("Images", orderedImages).Where("id = ?", id).First(&product).Error
 	if err != nil {
 		return nil, err
 	}
@@ -141,9 +366,40 @@ func (r *productsRepository) FindById(id uuid.UUID) (*models.Product, error) {
 }
 
 // product upadation
-func (r *productsRepository) UpdateProduct(product *models.Product) error {
-	// Use Session to ensure associations are saved
-	return r.DB.Session(&gorm.Session{FullSaveAssociations: true}).Save(product).Error
+func (r *productsRepository) UpdateProduct(product *models.Product, actorID uuid.UUID) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var current struct {
+			Slug       string
+			StockCount int
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Model(&models.Product{}).
+			Where("id = ?", product.ID).
+			Select("slug", "stock_count").
+			Scan(&current).Error; err != nil {
+			return err
+		}
+
+		// a changed slug leaves a redirect behind so old links keep working
+		if err := recordSlugChange(tx, constent.SlugEntityProduct, product.ID, current.Slug, product.Slug); err != nil {
+			return err
+		}
+
+		// an edited stock count goes into the ledger as an admin adjustment
+		if err := recordStockMovement(tx, &models.StockMovement{
+			ProductID:     product.ID,
+			Delta:         product.StockCount - current.StockCount,
+			Reason:        enums.MovementAdminAdjustment,
+			ReferenceType: constent.StockRefProduct,
+			ReferenceID:   &product.ID,
+			ActorID:       optionalActor(actorID),
+		}); err != nil {
+			return err
+		}
+
+		// Use Session to ensure associations are saved
+		return tx.Session(&gorm.Session{FullSaveAssociations: true}).Save(product).Error
+	})
 }
 
 // delete product and related images that is not needed
@@ -156,8 +412,8 @@ func (r *productsRepository) DeleteImagesNotIn(productID uuid.UUID, urlsToKeep [
 		query = query.Where("url NOT IN ?", urlsToKeep)
 	}
 
-	// Fetch only the fields we need (ID and URL) for better performance
-	if err := query.Select("id", "url").Find(&toDelete).Error; err != nil {
+	// Fetch only the fields we need (ID and URLs) for better performance
+	if err := query.Select("id", "url", "thumbnail_url", "card_url", "zoom_url").Find(&toDelete).Error; err != nil {
 		return nil, fmt.Errorf("failed to fetch images: %w", err)
 	}
 
@@ -165,12 +421,12 @@ func (r *productsRepository) DeleteImagesNotIn(productID uuid.UUID, urlsToKeep [
 		return []string{}, nil
 	}
 
-	// Extract IDs and URLs in a single loop
+	// Extract IDs and URLs (original + derivatives) in a single loop
 	ids := make([]uuid.UUID, len(toDelete))
-	urls := make([]string, len(toDelete))
+	urls := make([]string, 0, len(toDelete))
 	for i, img := range toDelete {
 		ids[i] = img.ID
-		urls[i] = img.URL
+		urls = append(urls, img.AllURLs()...)
 	}
 
 	// Delete images
@@ -197,16 +453,334 @@ func (r *productsRepository) ToggleActive(id uuid.UUID) error {
 	return nil
 }
 
+// DeleteProduct soft-deletes the product and its live images with the same deleted_at,
+// so RestoreProduct can tell them apart from images that were removed earlier
 func (r *productsRepository) DeleteProduct(id uuid.UUID) error {
-	result := r.DB.Delete(&models.Product{}, id)
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		result := tx.Model(&models.Product{}).Where("id = ?", id).Update("deleted_at", now)
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete product: %w", result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("product not found with id: %s", id)
+		}
+
+		if err := tx.Model(&models.ProductImage{}).Where("product_id = ?", id).Update("deleted_at", now).Error; err != nil {
+			return fmt.Errorf("failed to delete product images: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// RestoreProduct undoes DeleteProduct, bringing back the images deleted together with it
+func (r *productsRepository) RestoreProduct(id uuid.UUID) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var product models.Product
+		err := tx.Unscoped().
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND deleted_at IS NOT NULL", id).
+			First(&product).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("product not found in trash with id: %s", id)
+			}
+			return err
+		}
+
+		if err := tx.Unscoped().Model(&models.ProductImage{}).
+			Where("product_id = ? AND deleted_at = ?", id, product.DeletedAt.Time).
+			Update("deleted_at", nil).Error; err != nil {
+			return fmt.Errorf("failed to restore product images: %w", err)
+		}
+
+		if err := tx.Unscoped().Model(&models.Product{}).
+			Where("id = ?", id).
+			Update("deleted_at", nil).Error; err != nil {
+			return fmt.Errorf("failed to restore product: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// FindDeletedProducts lists the trash, most recently deleted first
+func (r *productsRepository) FindDeletedProducts(limit, offset int) ([]models.Product, int64, error) {
+	var products []models.Product
+	var total int64
+
+	db := r.DB.Unscoped().Model(&models.Product{}).Where("deleted_at IS NOT NULL")
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := db.
+		Preload("Images", func(db *gorm.DB) *gorm.DB {
+			return orderedImages(db.Unscoped())
+		}).
+		Preload("Category").
+		Order("deleted_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&products).Error
+
+	return products, total, err
+}
+
+// PurgeDeletedBefore hard-deletes products that have been in the trash since before cutoff.
+// Images and variants cascade, order items keep their snapshot (product_id is set to NULL).
+// Returns the image URLs so the caller can remove the stored files.
+func (r *productsRepository) PurgeDeletedBefore(cutoff time.Time) (int64, []string, error) {
+	var purged int64
+	var urls []string
+
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		var ids []uuid.UUID
+		if err := tx.Unscoped().Model(&models.Product{}).
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+
+		if len(ids) == 0 {
+			return nil
+		}
+
+		var images []models.ProductImage
+		if err := tx.Unscoped().
+			Where("product_id IN ?", ids).
+			Find(&images).Error; err != nil {
+			return err
+		}
+		for _, img := range images {
+			urls = append(urls, img.AllURLs()...)
+		}
+
+		result := tx.Unscoped().Where("id IN ?", ids).Delete(&models.Product{})
+		if result.Error != nil {
+			return fmt.Errorf("failed to purge products: %w", result.Error)
+		}
+		purged = result.RowsAffected
+
+		return nil
+	})
+
+	return purged, urls, err
+}
+
+// ---------------- images ----------------
+
+func (r *productsRepository) FindImages(productID uuid.UUID) ([]models.ProductImage, error) {
+	var images []models.ProductImage
+	err := orderedImages(r.DB.Where("product_id = ?", productID)).Find(&images).Error
+	return images, err
+}
+
+// ReorderImages sets priority from the position in imageIDs, which must list exactly the product's live images
+func (r *productsRepository) ReorderImages(productID uuid.UUID, imageIDs []uuid.UUID) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		return reorderImages(tx, productID, imageIDs)
+	})
+}
+
+// SetPrimaryImage moves one image to the front and keeps the others in their current order
+func (r *productsRepository) SetPrimaryImage(productID, imageID uuid.UUID) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var current []uuid.UUID
+		if err := orderedImages(tx.Model(&models.ProductImage{}).Where("product_id = ?", productID)).
+			Pluck("id", &current).Error; err != nil {
+			return err
+		}
+
+		order := []uuid.UUID{imageID}
+		found := false
+		for _, id := range current {
+			if id == imageID {
+				found = true
+				continue
+			}
+			order = append(order, id)
+		}
+		if !found {
+			return fmt.Errorf("image not found for product %s", productID)
+		}
+
+		return reorderImages(tx, productID, order)
+	})
+}
+
+func reorderImages(tx *gorm.DB, productID uuid.UUID, imageIDs []uuid.UUID) error {
+	var images []models.ProductImage
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("product_id = ?", productID).
+		Find(&images).Error; err != nil {
+		return err
+	}
+
+	if len(images) == 0 {
+		return fmt.Errorf("product not found or has no images: %s", productID)
+	}
+
+	live := make(map[uuid.UUID]bool, len(images))
+	for _, img := range images {
+		live[img.ID] = true
+	}
+
+	if len(imageIDs) != len(images) {
+		return fmt.Errorf("invalid image order: expected all %d images of the product", len(images))
+	}
+
+	for i, id := range imageIDs {
+		if !live[id] {
+			return fmt.Errorf("invalid image order: image %s not found for product or listed twice", id)
+		}
+		delete(live, id)
+
+		if err := tx.Model(&models.ProductImage{}).Where("id = ?", id).Update("priority", i).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *productsRepository) UpdateImageAltText(productID, imageID uuid.UUID, altText string) error {
+	result := r.DB.Model(&models.ProductImage{}).
+		Where("id = ? AND product_id = ?", imageID, productID).
+		Update("alt_text", altText)
 
 	if result.Error != nil {
-		return fmt.Errorf("failed to delete product: %w", result.Error)
+		return result.Error
 	}
 
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("product not found with id: %s", id)
+		return fmt.Errorf("image not found for product %s", productID)
 	}
 
 	return nil
 }
+
+// ---------------- bulk import / export ----------------
+
+// Transaction runs fn with a repository bound to one DB transaction
+func (r *productsRepository) Transaction(fn func(repo interfaces.ProductsRepository) error) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		return fn(&productsRepository{DB: *tx})
+	})
+}
+
+// FindByName matches a live product by name, ignoring case (used to upsert CSV rows)
+func (r *productsRepository) FindByName(name string) (*models.Product, error) {
+	var product models.Product
+	err := r.DB.Preload("Images", orderedImages).Where("LOWER(name) = LOWER(?)", name).First(&product).Error
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// FindAllForExport loads the whole live catalog with images and category
+func (r *productsRepository) FindAllForExport() ([]models.Product, error) {
+	var products []models.Product
+	err := r.DB.
+		Preload("Images", orderedImages).
+		Preload("Category").
+		Order("created_at ASC, id ASC").
+		Find(&products).Error
+	return products, err
+}
+
+// ---------------- variants ----------------
+
+func (r *productsRepository) FindVariantsByProduct(productID uuid.UUID) ([]models.ProductVariant, error) {
+	var variants []models.ProductVariant
+
+	err := r.DB.
+		Where("product_id = ?", productID).
+		Order("created_at ASC").
+		Find(&variants).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return variants, nil
+}
+
+func (r *productsRepository) FindVariantByID(productID, variantID uuid.UUID) (*models.ProductVariant, error) {
+	var variant models.ProductVariant
+
+	err := r.DB.
+		Where("id = ? AND product_id = ?", variantID, productID).
+		First(&variant).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("variant not found with id: %s", variantID)
+		}
+		return nil, err
+	}
+
+	return &variant, nil
+}
+
+// CreateVariant adds a variant and keeps the product stock as the sum of its variants
+func (r *productsRepository) CreateVariant(variant *models.ProductVariant, actorID uuid.UUID) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var product models.Product
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", variant.ProductID).
+			First(&product).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("product not found with id: %s", variant.ProductID)
+			}
+			return err
+		}
+
+		if err := tx.Create(variant).Error; err != nil {
+			return fmt.Errorf("failed to create variant: %w", err)
+		}
+
+		return syncProductStockLogged(tx, variant.ProductID, variantAdjustment(variant, actorID))
+	})
+}
+
+// UpdateVariant saves the variant and re-syncs the product stock
+func (r *productsRepository) UpdateVariant(variant *models.ProductVariant, actorID uuid.UUID) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", variant.ProductID).
+			First(&models.Product{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Save(variant).Error; err != nil {
+			return fmt.Errorf("failed to update variant: %w", err)
+		}
+
+		return syncProductStockLogged(tx, variant.ProductID, variantAdjustment(variant, actorID))
+	})
+}
+
+// variantAdjustment is the ledger line for an admin variant edit; the delta is filled in on sync
+func variantAdjustment(variant *models.ProductVariant, actorID uuid.UUID) *models.StockMovement {
+	return &models.StockMovement{
+		VariantID:     &variant.ID,
+		Reason:        enums.MovementAdminAdjustment,
+		ReferenceType: constent.StockRefVariant,
+		ReferenceID:   &variant.ID,
+		ActorID:       optionalActor(actorID),
+	}
+}
+
+// syncProductStock sets products.stock_count to the total stock of its active variants
+func syncProductStock(tx *gorm.DB, productID uuid.UUID) error {
+	return tx.Model(&models.Product{}).
+		Where("id = ?", productID).
+		Update("stock_count", gorm.Expr(
+			"(SELECT COALESCE(SUM(stock_count), 0) FROM product_variants WHERE product_id = ? AND is_active = TRUE AND deleted_at IS NULL)",
+			productID,
+		)).Error
+}
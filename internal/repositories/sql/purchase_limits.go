@@ -0,0 +1,86 @@
+package sql
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// checkPurchaseLimit enforces max_per_customer: what the user ordered since limitSince plus
+// the units about to be held (productQty for the product, variantQty for the chosen size)
+// must stay within the product cap and the size cap. Cancelled orders and lines don't count.
+func checkPurchaseLimit(tx *gorm.DB, userID uuid.UUID, product *models.Product, variant *models.ProductVariant, productQty, variantQty int, limitSince time.Time) error {
+	if product.MaxPerCustomer != nil {
+		bought, err := purchasedQuantity(tx, userID, product.ID, nil, limitSince)
+		if err != nil {
+			return err
+		}
+		if overLimit(*product.MaxPerCustomer, bought, productQty) {
+			return fmt.Errorf("purchase limit exceeded: at most %d of %s per customer (%d already ordered)",
+				*product.MaxPerCustomer, product.Name, bought)
+		}
+	}
+
+	if variant != nil && variant.MaxPerCustomer != nil {
+		bought, err := purchasedQuantity(tx, userID, product.ID, &variant.ID, limitSince)
+		if err != nil {
+			return err
+		}
+		if overLimit(*variant.MaxPerCustomer, bought, variantQty) {
+			return fmt.Errorf("purchase limit exceeded: at most %d of %s size %s per customer (%d already ordered)",
+				*variant.MaxPerCustomer, product.Name, variant.Size, bought)
+		}
+	}
+
+	return nil
+}
+
+// overLimit reports whether qty more units on top of what was already bought break the cap
+func overLimit(limit, bought, qty int) bool {
+	return bought+qty > limit
+}
+
+// cartPurchaseTotals sums a cart's units per product and per size (variant), the quantities
+// the purchase limits are checked against
+func cartPurchaseTotals(items []models.CartItem) (map[uuid.UUID]int, map[uuid.UUID]int) {
+	productQty := make(map[uuid.UUID]int)
+	variantQty := make(map[uuid.UUID]int)
+	for _, ci := range items {
+		productQty[ci.ProductID] += ci.Quantity
+		if ci.VariantID != nil {
+			variantQty[*ci.VariantID] += ci.Quantity
+		}
+	}
+	return productQty, variantQty
+}
+
+// purchasedQuantity sums the user's non-cancelled order lines for a product (or one of its sizes)
+func purchasedQuantity(tx *gorm.DB, userID, productID uuid.UUID, variantID *uuid.UUID, limitSince time.Time) (int, error) {
+	query := tx.Model(&models.OrderItem{}).
+		Joins("JOIN orders ON orders.id = order_items.order_id").
+		Where("orders.user_id = ? AND order_items.product_id = ?", userID, productID).
+		Where("orders.status <> ? AND orders.cancelled_at IS NULL AND order_items.cancelled_at IS NULL", "cancelled").
+		Where("orders.created_at >= ?", limitSince)
+	if variantID != nil {
+		query = query.Where("order_items.variant_id = ?", *variantID)
+	}
+
+	var total int
+	err := query.Select("COALESCE(SUM(order_items.quantity), 0)").Scan(&total).Error
+	return total, err
+}
+
+// cartQuantity sums what the user's cart already holds of a product (or one of its sizes)
+func cartQuantity(tx *gorm.DB, cartID, productID uuid.UUID, variantID *uuid.UUID) (int, error) {
+	query := tx.Model(&models.CartItem{}).Where("cart_id = ? AND product_id = ?", cartID, productID)
+	if variantID != nil {
+		query = query.Where("variant_id = ?", *variantID)
+	}
+
+	var total int
+	err := query.Select("COALESCE(SUM(quantity), 0)").Scan(&total).Error
+	return total, err
+}
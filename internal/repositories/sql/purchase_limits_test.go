@@ -0,0 +1,68 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestCartPurchaseTotals(t *testing.T) {
+	shoe, socks := uuid.New(), uuid.New()
+	size9, size10 := uuid.New(), uuid.New()
+
+	items := []models.CartItem{
+		{ProductID: shoe, VariantID: &size9, Quantity: 1},
+		{ProductID: shoe, VariantID: &size10, Quantity: 1},
+		{ProductID: socks, Quantity: 3},
+	}
+
+	productQty, variantQty := cartPurchaseTotals(items)
+
+	if productQty[shoe] != 2 || productQty[socks] != 3 {
+		t.Errorf("product totals = %v, want shoe 2 and socks 3", productQty)
+	}
+	if variantQty[size9] != 1 || variantQty[size10] != 1 || len(variantQty) != 2 {
+		t.Errorf("size totals = %v, want one of each size", variantQty)
+	}
+}
+
+func TestOverLimit(t *testing.T) {
+	tests := []struct {
+		name               string
+		limit, bought, qty int
+		want               bool
+	}{
+		{name: "first order within the cap", limit: 2, bought: 0, qty: 2, want: false},
+		{name: "first order over the cap", limit: 2, bought: 0, qty: 3, want: true},
+		{name: "earlier orders fill the cap", limit: 2, bought: 2, qty: 1, want: true},
+		{name: "earlier orders leave room", limit: 3, bought: 1, qty: 2, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := overLimit(tt.limit, tt.bought, tt.qty); got != tt.want {
+				t.Fatalf("overLimit(%d, %d, %d) = %v, want %v", tt.limit, tt.bought, tt.qty, got, tt.want)
+			}
+		})
+	}
+}
+
+// Two sizes of a product capped at 2 per customer: the whole cart counts once against the cap,
+// so the order goes through when nothing was bought before
+func TestCartWithinProductCap(t *testing.T) {
+	shoe := uuid.New()
+	size9, size10 := uuid.New(), uuid.New()
+
+	productQty, _ := cartPurchaseTotals([]models.CartItem{
+		{ProductID: shoe, VariantID: &size9, Quantity: 1},
+		{ProductID: shoe, VariantID: &size10, Quantity: 1},
+	})
+
+	if overLimit(2, 0, productQty[shoe]) {
+		t.Fatalf("a cart of %d within a cap of 2 was rejected", productQty[shoe])
+	}
+	if !overLimit(2, 1, productQty[shoe]) {
+		t.Fatalf("a cart of %d after 1 earlier unit passed a cap of 2", productQty[shoe])
+	}
+}
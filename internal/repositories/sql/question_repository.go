@@ -0,0 +1,73 @@
+package sql
+
+import (
+	"errors"
+
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type questionRepository struct {
+	DB *gorm.DB
+}
+
+func NewQuestionRepository(db *gorm.DB) interfaces.QuestionRepository {
+	return &questionRepository{
+		DB: db,
+	}
+}
+
+func (r *questionRepository) CreateQuestion(question *models.ProductQuestion) error {
+	return r.DB.Create(question).Error
+}
+
+// FindQuestionByID loads the question with its asker and answers
+func (r *questionRepository) FindQuestionByID(id uuid.UUID) (*models.ProductQuestion, error) {
+	var question models.ProductQuestion
+	err := r.DB.
+		Preload("User").
+		Preload("Answers", answersOldestFirst).
+		Preload("Answers.User").
+		Where("id = ?", id).
+		First(&question).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("question not found")
+		}
+		return nil, err
+	}
+	return &question, nil
+}
+
+// FindByProduct pages a product's questions, newest first, each with its full answer thread
+func (r *questionRepository) FindByProduct(productID uuid.UUID, limit, offset int) ([]models.ProductQuestion, int64, error) {
+	var questions []models.ProductQuestion
+	var total int64
+
+	db := r.DB.Model(&models.ProductQuestion{}).Where("product_id = ?", productID)
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := db.
+		Preload("User").
+		Preload("Answers", answersOldestFirst).
+		Preload("Answers.User").
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&questions).Error
+
+	return questions, total, err
+}
+
+func (r *questionRepository) CreateAnswer(answer *models.ProductAnswer) error {
+	return r.DB.Create(answer).Error
+}
+
+func answersOldestFirst(db *gorm.DB) *gorm.DB {
+	return db.Order("created_at ASC, id ASC")
+}
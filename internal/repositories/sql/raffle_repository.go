@@ -0,0 +1,443 @@
+package sql
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	constent "github.com/akhilnasimk/SS_backend/internal/const"
+	"github.com/akhilnasimk/SS_backend/internal/enums"
+	"github.com/akhilnasimk/SS_backend/internal/helpers"
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type raffleRepository struct {
+	DB *gorm.DB
+}
+
+func NewRaffleRepository(db *gorm.DB) interfaces.RaffleRepository {
+	return &raffleRepository{
+		DB: db,
+	}
+}
+
+func (r *raffleRepository) Create(raffle *models.Raffle) error {
+	return r.DB.Create(raffle).Error
+}
+
+func (r *raffleRepository) FindByID(id uuid.UUID) (*models.Raffle, error) {
+	var raffle models.Raffle
+	err := r.DB.
+		Preload("Product").
+		Preload("Product.Images", orderedImages).
+		Where("id = ?", id).
+		First(&raffle).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("raffle not found")
+		}
+		return nil, err
+	}
+	return &raffle, nil
+}
+
+// FindOpen lists raffles that take entries now or will open later, closing soonest first
+func (r *raffleRepository) FindOpen(now time.Time) ([]models.Raffle, error) {
+	var raffles []models.Raffle
+	err := r.DB.
+		Preload("Product").
+		Preload("Product.Images", orderedImages).
+		Where("status = ? AND closes_at > ?", enums.RaffleOpen, now).
+		Order("closes_at ASC, id ASC").
+		Find(&raffles).Error
+	return raffles, err
+}
+
+func (r *raffleRepository) CountEntries(raffleID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.DB.Model(&models.RaffleEntry{}).Where("raffle_id = ?", raffleID).Count(&count).Error
+	return count, err
+}
+
+// CreateEntry checks the entry window and the size under a lock on the raffle
+func (r *raffleRepository) CreateEntry(entry *models.RaffleEntry, now time.Time) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		raffle, err := lockRaffle(tx, entry.RaffleID)
+		if err != nil {
+			return err
+		}
+
+		if raffle.Status != enums.RaffleOpen || now.Before(raffle.OpensAt) || !now.Before(raffle.ClosesAt) {
+			return errors.New("raffle is not open for entries")
+		}
+		entry.ProductID = raffle.ProductID
+
+		// one size per entry when the product comes in sizes
+		if entry.VariantID != nil {
+			if _, err := lockVariant(tx, raffle.ProductID, *entry.VariantID); err != nil {
+				return err
+			}
+		} else {
+			needsVariant, err := hasActiveVariants(tx, raffle.ProductID)
+			if err != nil {
+				return err
+			}
+			if needsVariant {
+				return errors.New("please select a size")
+			}
+		}
+
+		var count int64
+		if err := tx.Model(&models.RaffleEntry{}).
+			Where("raffle_id = ? AND user_id = ?", entry.RaffleID, entry.UserID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return errors.New("you already entered this raffle")
+		}
+
+		entry.Status = enums.EntryEntered
+		return tx.Create(entry).Error
+	})
+}
+
+// FindEntry returns nil, nil when the user has not entered
+func (r *raffleRepository) FindEntry(raffleID, userID uuid.UUID) (*models.RaffleEntry, error) {
+	var entry models.RaffleEntry
+	err := r.DB.Preload("Variant").
+		Where("raffle_id = ? AND user_id = ?", raffleID, userID).
+		First(&entry).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// FindEntries lists all entries in draw order (undrawn ones by entry time)
+func (r *raffleRepository) FindEntries(raffleID uuid.UUID) ([]models.RaffleEntry, error) {
+	var entries []models.RaffleEntry
+	err := r.DB.
+		Preload("User").
+		Preload("Variant").
+		Where("raffle_id = ?", raffleID).
+		Order("draw_position ASC NULLS LAST, created_at ASC, id ASC").
+		Find(&entries).Error
+	return entries, err
+}
+
+// Draw ranks every entry by its ticket from the seed committed at creation and walks the
+// ranking, giving each entrant their size while stock lasts. A winner's unit is taken from
+// stock right away and held until the reservation is purchased or expires.
+func (r *raffleRepository) Draw(raffleID uuid.UUID, drawnBy uuid.UUID, now time.Time) (*models.Raffle, []models.RaffleEntry, error) {
+	var raffle *models.Raffle
+	var winners []models.RaffleEntry
+
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		raffle, err = lockRaffle(tx, raffleID)
+		if err != nil {
+			return err
+		}
+
+		if raffle.Status != enums.RaffleOpen {
+			return errors.New("raffle already drawn")
+		}
+		if now.Before(raffle.ClosesAt) {
+			return errors.New("raffle is still open for entries")
+		}
+		if raffle.Seed == "" {
+			return errors.New("raffle has no committed seed")
+		}
+
+		var entries []models.RaffleEntry
+		if err := tx.Where("raffle_id = ?", raffleID).Find(&entries).Error; err != nil {
+			return err
+		}
+
+		rankRaffleEntries(entries, raffle.Seed)
+
+		// lock the stock the winners are drawn against
+		var product models.Product
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", raffle.ProductID).
+			First(&product).Error; err != nil {
+			return err
+		}
+
+		var variants []models.ProductVariant
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("product_id = ? AND is_active = ?", raffle.ProductID, true).
+			Find(&variants).Error; err != nil {
+			return err
+		}
+		sizeStock := make(map[uuid.UUID]int, len(variants))
+		for _, v := range variants {
+			sizeStock[v.ID] = v.StockCount
+		}
+
+		productStock := allocateRaffleWins(entries, product.StockCount, sizeStock)
+		expiresAt := now.Add(time.Duration(raffle.ReservationHours) * time.Hour)
+
+		for i := range entries {
+			e := &entries[i]
+
+			updates := map[string]interface{}{
+				"ticket":        e.Ticket,
+				"draw_position": *e.DrawPosition,
+				"status":        e.Status,
+			}
+
+			if e.Status == enums.EntryWon {
+				e.ReservationExpiresAt = &expiresAt
+				updates["reservation_expires_at"] = expiresAt
+
+				if e.VariantID != nil {
+					if err := adjustVariantStock(tx, *e.VariantID, -1); err != nil {
+						return err
+					}
+				}
+				if err := recordStockMovement(tx, &models.StockMovement{
+					ProductID:     raffle.ProductID,
+					VariantID:     e.VariantID,
+					Delta:         -1,
+					Reason:        enums.MovementSale,
+					ReferenceType: constent.StockRefRaffleEntry,
+					ReferenceID:   &e.ID,
+					ActorID:       &drawnBy,
+					Note:          "raffle win reservation",
+				}); err != nil {
+					return err
+				}
+				winners = append(winners, *e)
+			}
+
+			if err := tx.Model(&models.RaffleEntry{}).Where("id = ?", e.ID).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&product).Update("stock_count", productStock).Error; err != nil {
+			return err
+		}
+
+		raffle.Status = enums.RaffleDrawn
+		raffle.DrawnAt = &now
+		raffle.DrawnBy = &drawnBy
+		raffle.WinnerCount = len(winners)
+
+		return tx.Model(&models.Raffle{}).Where("id = ?", raffle.ID).Updates(map[string]interface{}{
+			"status":       raffle.Status,
+			"drawn_at":     now,
+			"drawn_by":     drawnBy,
+			"winner_count": raffle.WinnerCount,
+		}).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return raffle, winners, nil
+}
+
+// rankRaffleEntries gives every entry its ticket for the seed and sorts the entries into draw
+// order, lowest ticket first (entry id breaks a tie)
+func rankRaffleEntries(entries []models.RaffleEntry, seed string) {
+	for i := range entries {
+		entries[i].Ticket = helpers.RaffleTicket(seed, entries[i].ID)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Ticket != entries[j].Ticket {
+			return entries[i].Ticket < entries[j].Ticket
+		}
+		return entries[i].ID.String() < entries[j].ID.String()
+	})
+}
+
+// allocateRaffleWins walks ranked entries, numbering them and marking each a win while the
+// product has stock and, for a size entry, that size has stock too. sizeStock is used up in
+// place; the product stock left after the winners is returned.
+func allocateRaffleWins(entries []models.RaffleEntry, productStock int, sizeStock map[uuid.UUID]int) int {
+	for i := range entries {
+		e := &entries[i]
+		position := i + 1
+		e.DrawPosition = &position
+		e.Status = enums.EntryLost
+
+		if productStock > 0 {
+			if e.VariantID == nil {
+				e.Status = enums.EntryWon
+			} else if sizeStock[*e.VariantID] > 0 {
+				sizeStock[*e.VariantID]--
+				e.Status = enums.EntryWon
+			}
+		}
+		if e.Status == enums.EntryWon {
+			productStock--
+		}
+	}
+	return productStock
+}
+
+// PurchaseReservation turns a winning entry into an order of one unit. It bypasses the cart
+// and the drop window on purpose; the unit was already taken from stock by the draw.
+func (r *raffleRepository) PurchaseReservation(raffleID, userID uuid.UUID, order *models.Order, now time.Time) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		var entry models.RaffleEntry
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("raffle_id = ? AND user_id = ?", raffleID, userID).
+			First(&entry).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("raffle entry not found")
+			}
+			return err
+		}
+
+		switch {
+		case entry.Status == enums.EntryPurchased:
+			return errors.New("reservation already used")
+		case entry.Status == enums.EntryExpired,
+			entry.Status == enums.EntryWon && entry.ReservationExpiresAt != nil && !now.Before(*entry.ReservationExpiresAt):
+			return errors.New("reservation has expired")
+		case entry.Status != enums.EntryWon:
+			return errors.New("no winning reservation for this raffle")
+		}
+
+		var product models.Product
+		if err := tx.Unscoped().
+			Preload("Images", primaryImage).
+			Where("id = ?", entry.ProductID).
+			First(&product).Error; err != nil {
+			return err
+		}
+
+		var variant *models.ProductVariant
+		if entry.VariantID != nil {
+			variant = &models.ProductVariant{}
+			if err := tx.Where("id = ?", *entry.VariantID).First(variant).Error; err != nil {
+				return fmt.Errorf("reserved size not found: %w", err)
+			}
+		}
+
+		price := product.Price
+		if variant != nil {
+			price = variant.EffectivePrice(product.Price)
+		}
+
+		order.TotalAmount = float64(price)
+		if err := tx.Create(order).Error; err != nil {
+			return err
+		}
+
+		var productImage string
+		if len(product.Images) > 0 {
+			productImage = product.Images[0].SizedURL(constent.ImageSizeThumbnail)
+		}
+
+		orderItem := models.OrderItem{
+			OrderID:      order.ID,
+			ProductID:    product.ID,
+			VariantID:    entry.VariantID,
+			ProductName:  product.Name,
+			ProductImage: productImage,
+			Quantity:     1,
+			Price:        float64(price),
+			TotalPrice:   float64(price),
+		}
+		snapshotVariant(&orderItem, variant)
+
+		if err := tx.Create(&orderItem).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&entry).Updates(map[string]interface{}{
+			"status":   enums.EntryPurchased,
+			"order_id": order.ID,
+		}).Error
+	})
+}
+
+// ExpireReservations releases lapsed winning reservations and puts their units back in stock
+func (r *raffleRepository) ExpireReservations(now time.Time) (int64, error) {
+	var expired int64
+
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		var entries []models.RaffleEntry
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND reservation_expires_at <= ?", enums.EntryWon, now).
+			Find(&entries).Error; err != nil {
+			return err
+		}
+
+		for _, e := range entries {
+			if err := tx.Unscoped().Model(&models.Product{}).
+				Where("id = ?", e.ProductID).
+				Update("stock_count", gorm.Expr("stock_count + 1")).Error; err != nil {
+				return err
+			}
+			if e.VariantID != nil {
+				if err := adjustVariantStock(tx, *e.VariantID, 1); err != nil {
+					return err
+				}
+			}
+			if err := recordStockMovement(tx, &models.StockMovement{
+				ProductID:     e.ProductID,
+				VariantID:     e.VariantID,
+				Delta:         1,
+				Reason:        enums.MovementCancellation,
+				ReferenceType: constent.StockRefRaffleEntry,
+				ReferenceID:   &e.ID,
+				Note:          "raffle reservation expired",
+			}); err != nil {
+				return err
+			}
+
+			if err := tx.Model(&models.RaffleEntry{}).Where("id = ?", e.ID).
+				Update("status", enums.EntryExpired).Error; err != nil {
+				return err
+			}
+		}
+
+		expired = int64(len(entries))
+		return nil
+	})
+
+	return expired, err
+}
+
+// lockRaffle loads the raffle row FOR UPDATE
+func lockRaffle(tx *gorm.DB, id uuid.UUID) (*models.Raffle, error) {
+	var raffle models.Raffle
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&raffle).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("raffle not found")
+		}
+		return nil, err
+	}
+	return &raffle, nil
+}
+
+// checkNoOpenRaffle keeps first-come carts and orders off a product while a raffle for it is
+// undrawn: the stock belongs to the draw, and winners buy through PurchaseReservation
+func checkNoOpenRaffle(tx *gorm.DB, product *models.Product) error {
+	var open int64
+	if err := tx.Model(&models.Raffle{}).
+		Where("product_id = ? AND status = ?", product.ID, enums.RaffleOpen).
+		Count(&open).Error; err != nil {
+		return err
+	}
+	if open > 0 {
+		return fmt.Errorf("product %s is released by raffle, enter the raffle to buy it", product.Name)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/akhilnasimk/SS_backend/internal/enums"
+	"github.com/akhilnasimk/SS_backend/internal/helpers"
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/google/uuid"
+)
+
+func TestRankRaffleEntries(t *testing.T) {
+	const seed = "0f3a9c1e5b7d2468ace13579bdf02468"
+
+	entries := make([]models.RaffleEntry, 20)
+	for i := range entries {
+		entries[i].ID = uuid.New()
+	}
+	again := make([]models.RaffleEntry, len(entries))
+	copy(again, entries)
+	// the order entries come from the database must not matter
+	for i, j := 0, len(again)-1; i < j; i, j = i+1, j-1 {
+		again[i], again[j] = again[j], again[i]
+	}
+
+	rankRaffleEntries(entries, seed)
+	rankRaffleEntries(again, seed)
+
+	for i := range entries {
+		if entries[i].Ticket != helpers.RaffleTicket(seed, entries[i].ID) {
+			t.Fatalf("entry %d ticket is not sha256(seed:id)", i)
+		}
+		if i > 0 && entries[i-1].Ticket > entries[i].Ticket {
+			t.Fatalf("entries are not sorted by ticket at %d", i)
+		}
+		if entries[i].ID != again[i].ID {
+			t.Fatalf("ranking depends on input order at %d", i)
+		}
+	}
+}
+
+func TestAllocateRaffleWins(t *testing.T) {
+	size9, size10 := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name         string
+		sizes        []*uuid.UUID // one entry per element, in draw order
+		productStock int
+		sizeStock    map[uuid.UUID]int
+		want         []enums.RaffleEntryStatus
+		wantLeft     int
+	}{
+		{
+			name:         "no sizes, winners up to the stock",
+			sizes:        []*uuid.UUID{nil, nil, nil},
+			productStock: 2,
+			want:         []enums.RaffleEntryStatus{enums.EntryWon, enums.EntryWon, enums.EntryLost},
+			wantLeft:     0,
+		},
+		{
+			name:         "a sold out size loses, later sizes still win",
+			sizes:        []*uuid.UUID{&size9, &size9, &size10},
+			productStock: 2,
+			sizeStock:    map[uuid.UUID]int{size9: 1, size10: 1},
+			want:         []enums.RaffleEntryStatus{enums.EntryWon, enums.EntryLost, enums.EntryWon},
+			wantLeft:     0,
+		},
+		{
+			name:         "product stock caps the sizes",
+			sizes:        []*uuid.UUID{&size9, &size10},
+			productStock: 1,
+			sizeStock:    map[uuid.UUID]int{size9: 5, size10: 5},
+			want:         []enums.RaffleEntryStatus{enums.EntryWon, enums.EntryLost},
+			wantLeft:     0,
+		},
+		{
+			name:         "more stock than entries",
+			sizes:        []*uuid.UUID{&size9},
+			productStock: 4,
+			sizeStock:    map[uuid.UUID]int{size9: 4},
+			want:         []enums.RaffleEntryStatus{enums.EntryWon},
+			wantLeft:     3,
+		},
+		{
+			name:         "no stock",
+			sizes:        []*uuid.UUID{nil, &size9},
+			productStock: 0,
+			sizeStock:    map[uuid.UUID]int{size9: 1},
+			want:         []enums.RaffleEntryStatus{enums.EntryLost, enums.EntryLost},
+			wantLeft:     0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := make([]models.RaffleEntry, len(tt.sizes))
+			for i, size := range tt.sizes {
+				entries[i] = models.RaffleEntry{ID: uuid.New(), VariantID: size}
+			}
+			sizeStock := make(map[uuid.UUID]int)
+			for id, n := range tt.sizeStock {
+				sizeStock[id] = n
+			}
+
+			left := allocateRaffleWins(entries, tt.productStock, sizeStock)
+
+			if left != tt.wantLeft {
+				t.Errorf("product stock left = %d, want %d", left, tt.wantLeft)
+			}
+			for i, e := range entries {
+				if e.Status != tt.want[i] {
+					t.Errorf("entry %d status = %s, want %s", i, e.Status, tt.want[i])
+				}
+				if e.DrawPosition == nil || *e.DrawPosition != i+1 {
+					t.Errorf("entry %d draw position = %v, want %d", i, e.DrawPosition, i+1)
+				}
+			}
+		})
+	}
+}
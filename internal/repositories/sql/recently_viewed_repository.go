@@ -0,0 +1,117 @@
+package sql
+
+import (
+	"errors"
+
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type recentlyViewedRepository struct {
+	DB *gorm.DB
+}
+
+func NewRecentlyViewedRepository(db *gorm.DB) interfaces.RecentlyViewedRepository {
+	return &recentlyViewedRepository{
+		DB: db,
+	}
+}
+
+// RecordView upserts the (owner, product) row with a fresh viewed_at and trims the owner's list to keep entries
+func (r *recentlyViewedRepository) RecordView(view *models.RecentlyViewed, keep int) error {
+	column, owner, err := recentOwner(view)
+	if err != nil {
+		return err
+	}
+
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: column}, {Name: "product_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"viewed_at"}),
+		}).Create(view).Error; err != nil {
+			return err
+		}
+
+		return trimRecentlyViewed(tx, column, owner, keep)
+	})
+}
+
+func (r *recentlyViewedRepository) FindByUser(userID uuid.UUID, limit int) ([]models.RecentlyViewed, error) {
+	return r.findByOwner("user_id", userID, limit)
+}
+
+func (r *recentlyViewedRepository) FindByGuest(guestID uuid.UUID, limit int) ([]models.RecentlyViewed, error) {
+	return r.findByOwner("guest_id", guestID, limit)
+}
+
+// MergeGuest moves a guest's views onto the user; for products seen by both the latest view wins
+func (r *recentlyViewedRepository) MergeGuest(guestID, userID uuid.UUID, keep int) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`
+			UPDATE recently_viewed u SET viewed_at = g.viewed_at
+			FROM recently_viewed g
+			WHERE g.guest_id = ? AND u.user_id = ? AND u.product_id = g.product_id AND g.viewed_at > u.viewed_at`,
+			guestID, userID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec(`
+			DELETE FROM recently_viewed g
+			WHERE g.guest_id = ?
+				AND EXISTS (SELECT 1 FROM recently_viewed u WHERE u.user_id = ? AND u.product_id = g.product_id)`,
+			guestID, userID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.RecentlyViewed{}).
+			Where("guest_id = ?", guestID).
+			Updates(map[string]interface{}{"user_id": userID, "guest_id": nil}).Error; err != nil {
+			return err
+		}
+
+		return trimRecentlyViewed(tx, "user_id", userID, keep)
+	})
+}
+
+// findByOwner lists views newest first, leaving out products that were deactivated or deleted since
+func (r *recentlyViewedRepository) findByOwner(column string, owner uuid.UUID, limit int) ([]models.RecentlyViewed, error) {
+	var views []models.RecentlyViewed
+
+	err := r.DB.
+		Joins("JOIN products ON products.id = recently_viewed.product_id AND products.deleted_at IS NULL AND products.is_active = TRUE").
+		Where("recently_viewed."+column+" = ?", owner).
+		Preload("Product").
+		Preload("Product.Images", orderedImages).
+		Preload("Product.Category").
+		Order("recently_viewed.viewed_at DESC, recently_viewed.id DESC").
+		Limit(limit).
+		Find(&views).Error
+
+	return views, err
+}
+
+// trimRecentlyViewed keeps only the owner's newest keep rows
+func trimRecentlyViewed(tx *gorm.DB, column string, owner uuid.UUID, keep int) error {
+	return tx.Exec(`
+		DELETE FROM recently_viewed
+		WHERE `+column+` = ? AND id NOT IN (
+			SELECT id FROM recently_viewed WHERE `+column+` = ?
+			ORDER BY viewed_at DESC, id DESC
+			LIMIT ?
+		)`, owner, owner, keep).Error
+}
+
+// recentOwner picks the owner column of a view: user when logged in, guest otherwise
+func recentOwner(view *models.RecentlyViewed) (string, uuid.UUID, error) {
+	switch {
+	case view.UserID != nil:
+		return "user_id", *view.UserID, nil
+	case view.GuestID != nil:
+		return "guest_id", *view.GuestID, nil
+	default:
+		return "", uuid.Nil, errors.New("view has no user or guest")
+	}
+}
@@ -0,0 +1,73 @@
+package sql
+
+import (
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type recommendationRepository struct {
+	DB *gorm.DB
+}
+
+func NewRecommendationRepository(db *gorm.DB) interfaces.RecommendationRepository {
+	return &recommendationRepository{
+		DB: db,
+	}
+}
+
+// coPurchaseSQL counts, for every ordered pair of products, the non-cancelled orders containing both
+const coPurchaseSQL = `
+	INSERT INTO product_co_purchases (product_id, related_product_id, order_count, updated_at)
+	SELECT a.product_id, b.product_id, COUNT(DISTINCT a.order_id), NOW()
+	FROM order_items a
+	JOIN order_items b ON b.order_id = a.order_id AND b.product_id <> a.product_id
+	JOIN orders o ON o.id = a.order_id
+	JOIN products pa ON pa.id = a.product_id
+	JOIN products pb ON pb.id = b.product_id
+	WHERE o.status <> 'cancelled'
+		AND a.cancelled_at IS NULL
+		AND b.cancelled_at IS NULL
+	GROUP BY a.product_id, b.product_id`
+
+// RefreshCoPurchases rebuilds the whole pair table in one transaction, readers keep
+// seeing the previous snapshot until it commits
+func (r *recommendationRepository) RefreshCoPurchases() (int64, error) {
+	var pairs int64
+
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM product_co_purchases").Error; err != nil {
+			return err
+		}
+
+		res := tx.Exec(coPurchaseSQL)
+		if res.Error != nil {
+			return res.Error
+		}
+		pairs = res.RowsAffected
+		return nil
+	})
+
+	return pairs, err
+}
+
+// FindCoPurchased returns the products most often bought with productID, active and in stock only
+func (r *recommendationRepository) FindCoPurchased(productID uuid.UUID, limit int) ([]models.Product, error) {
+	var products []models.Product
+
+	err := r.DB.Model(&models.Product{}).
+		Joins("JOIN product_co_purchases cp ON cp.related_product_id = products.id").
+		Where("cp.product_id = ?", productID).
+		Scopes(availableProducts(time.Now())).
+		Where("products.stock_count > 0").
+		Preload("Images", orderedImages).
+		Preload("Category").
+		Order("cp.order_count DESC, products.id ASC").
+		Limit(limit).
+		Find(&products).Error
+
+	return products, err
+}
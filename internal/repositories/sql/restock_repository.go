@@ -0,0 +1,84 @@
+package sql
+
+import (
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/enums"
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type restockRepository struct {
+	DB *gorm.DB
+}
+
+func NewRestockRepository(db *gorm.DB) interfaces.RestockRepository {
+	return &restockRepository{
+		DB: db,
+	}
+}
+
+// Subscribe adds the customer to the restock queue. A fulfilled subscription is re-armed and
+// goes to the back of the queue; a pending one is left untouched.
+func (r *restockRepository) Subscribe(sub *models.RestockSubscription) error {
+	return r.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "product_id"}, {Name: "user_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"status": enums.RestockPending, "notified_at": nil, "created_at": sub.CreatedAt}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Neq{Column: "restock_subscriptions.status", Value: enums.RestockPending},
+		}},
+	}).Create(sub).Error
+}
+
+func (r *restockRepository) Unsubscribe(userID, productID uuid.UUID) (int64, error) {
+	res := r.DB.
+		Where("user_id = ? AND product_id = ? AND status = ?", userID, productID, enums.RestockPending).
+		Delete(&models.RestockSubscription{})
+	return res.RowsAffected, res.Error
+}
+
+func (r *restockRepository) FindSubscription(userID, productID uuid.UUID) (*models.RestockSubscription, error) {
+	var sub models.RestockSubscription
+	if err := r.DB.Where("user_id = ? AND product_id = ?", userID, productID).First(&sub).Error; err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+// ClaimBatch marks up to limit pending subscriptions of products that have stock again as
+// fulfilled and returns them, oldest first. Subscriptions are only taken while a product is
+// sold out, so a pending row on a product with available stock means it was restocked, whichever
+// path raised the stock. Products outside their drop window wait until they can be bought, like
+// availableProducts. SKIP LOCKED keeps two instances from claiming the same rows.
+func (r *restockRepository) ClaimBatch(limit int, now time.Time) ([]interfaces.RestockNotice, error) {
+	var notices []interfaces.RestockNotice
+
+	err := r.DB.Raw(`
+		UPDATE restock_subscriptions s
+		SET status = ?, notified_at = ?
+		FROM users u, products p
+		WHERE s.id IN (
+			SELECT rs.id FROM restock_subscriptions rs
+			JOIN products rp ON rp.id = rs.product_id
+			JOIN users ru ON ru.id = rs.user_id AND ru.deleted_at IS NULL
+			WHERE rs.status = ?
+			  AND rp.deleted_at IS NULL AND rp.is_active = TRUE
+			  AND (rp.release_at IS NULL OR rp.release_at <= ?)
+			  AND (rp.ends_at IS NULL OR rp.ends_at > ?)
+			  AND rp.stock_count > COALESCE((
+				SELECT SUM(h.quantity) FROM stock_holds h
+				WHERE h.product_id = rp.id AND h.expires_at > ?), 0)
+			ORDER BY rs.created_at
+			LIMIT ?
+			FOR UPDATE OF rs SKIP LOCKED
+		)
+		AND u.id = s.user_id AND p.id = s.product_id
+		RETURNING s.id AS subscription_id, u.email, u.user_name, p.id AS product_id, p.name AS product_name`,
+		enums.RestockFulfilled, now, enums.RestockPending, now, now, now, limit).
+		Scan(&notices).Error
+
+	return notices, err
+}
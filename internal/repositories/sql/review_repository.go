@@ -0,0 +1,150 @@
+package sql
+
+import (
+	"errors"
+
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type reviewRepository struct {
+	DB *gorm.DB
+}
+
+func NewReviewRepository(db *gorm.DB) interfaces.ReviewRepository {
+	return &reviewRepository{
+		DB: db,
+	}
+}
+
+// Create saves the review with its photos and refreshes the product rating in the same transaction
+func (r *reviewRepository) Create(review *models.Review) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(review).Error; err != nil {
+			return err
+		}
+		return refreshProductRating(tx, review.ProductID)
+	})
+}
+
+// Update saves rating, title, body and inserts photos that are not stored yet
+func (r *reviewRepository) Update(review *models.Review) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Review{}).
+			Where("id = ?", review.ID).
+			Updates(map[string]interface{}{
+				"rating": review.Rating,
+				"title":  review.Title,
+				"body":   review.Body,
+			}).Error; err != nil {
+			return err
+		}
+
+		for i := range review.Photos {
+			if review.Photos[i].ID != uuid.Nil {
+				continue
+			}
+			review.Photos[i].ReviewID = review.ID
+			if err := tx.Create(&review.Photos[i]).Error; err != nil {
+				return err
+			}
+		}
+
+		return refreshProductRating(tx, review.ProductID)
+	})
+}
+
+func (r *reviewRepository) FindByID(id uuid.UUID) (*models.Review, error) {
+	var review models.Review
+	err := r.DB.Preload("User").Preload("Photos").Where("id = ?", id).First(&review).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("review not found")
+		}
+		return nil, err
+	}
+	return &review, nil
+}
+
+// FindByUserAndProduct returns nil, nil when the user has not reviewed the product
+func (r *reviewRepository) FindByUserAndProduct(userID, productID uuid.UUID) (*models.Review, error) {
+	var review models.Review
+	err := r.DB.Where("user_id = ? AND product_id = ?", userID, productID).First(&review).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &review, nil
+}
+
+// FindByProduct pages a product's reviews, newest first; hidden ones only for moderation
+func (r *reviewRepository) FindByProduct(productID uuid.UUID, limit, offset int, includeHidden bool) ([]models.Review, int64, error) {
+	var reviews []models.Review
+	var total int64
+
+	db := r.DB.Model(&models.Review{}).Where("product_id = ?", productID)
+	if !includeHidden {
+		db = db.Where("is_hidden = ?", false)
+	}
+
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := db.
+		Preload("User").
+		Preload("Photos", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&reviews).Error
+
+	return reviews, total, err
+}
+
+// SetHidden hides or shows a review and refreshes the product rating
+func (r *reviewRepository) SetHidden(id uuid.UUID, hidden bool, reason string) (*models.Review, error) {
+	var review models.Review
+
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", id).First(&review).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("review not found")
+			}
+			return err
+		}
+
+		if !hidden {
+			reason = ""
+		}
+
+		if err := tx.Model(&review).Updates(map[string]interface{}{
+			"is_hidden":     hidden,
+			"hidden_reason": reason,
+		}).Error; err != nil {
+			return err
+		}
+
+		return refreshProductRating(tx, review.ProductID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+// refreshProductRating recomputes the product's average rating and review count from visible reviews
+func refreshProductRating(tx *gorm.DB, productID uuid.UUID) error {
+	return tx.Exec(`
+		UPDATE products SET
+			average_rating = COALESCE((SELECT ROUND(AVG(rating)::numeric, 2) FROM reviews WHERE product_id = ? AND is_hidden = false), 0),
+			review_count = (SELECT COUNT(*) FROM reviews WHERE product_id = ? AND is_hidden = false)
+		WHERE id = ?`, productID, productID, productID).Error
+}
@@ -0,0 +1,44 @@
+package sql
+
+import (
+	"errors"
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/enums"
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// orderedImages is the one image ordering every read path uses: by priority
+// (0 = primary), then upload order. Use it in Preload("...Images", orderedImages).
+func orderedImages(db *gorm.DB) *gorm.DB {
+	return db.Order("product_images.priority ASC, product_images.created_at ASC, product_images.id ASC")
+}
+
+// primaryImage loads only the primary image; only for preloads of a single product
+func primaryImage(db *gorm.DB) *gorm.DB {
+	return orderedImages(db).Limit(1)
+}
+
+// availableProducts keeps products that are active and inside their drop window at t,
+// the SQL twin of models.Product.IsAvailableAt. Use it in db.Scopes(availableProducts(time.Now())).
+func availableProducts(t time.Time) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("products.is_active = ? AND (products.release_at IS NULL OR products.release_at <= ?) AND (products.ends_at IS NULL OR products.ends_at > ?)", true, t, t)
+	}
+}
+
+// checkProductAvailable is the purchase-time check for carts and orders
+func checkProductAvailable(product *models.Product, t time.Time) error {
+	if !product.IsActive {
+		return errors.New("product not found or inactive")
+	}
+
+	switch product.DropStatus(t) {
+	case enums.DropUpcoming:
+		return errors.New("product " + product.Name + " is not released yet")
+	case enums.DropEnded:
+		return errors.New("product " + product.Name + " is no longer available")
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package sql
+
+import (
+	"errors"
+
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type slugRedirectRepository struct {
+	DB *gorm.DB
+}
+
+func NewSlugRedirectRepository(db *gorm.DB) interfaces.SlugRedirectRepository {
+	return &slugRedirectRepository{
+		DB: db,
+	}
+}
+
+// FindRedirect returns nil, nil when the slug was never renamed away from
+func (r *slugRedirectRepository) FindRedirect(entityType, oldSlug string) (*models.SlugRedirect, error) {
+	var redirect models.SlugRedirect
+	err := r.DB.Where("entity_type = ? AND old_slug = ?", entityType, oldSlug).First(&redirect).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &redirect, nil
+}
+
+// recordSlugChange is called inside the rename transaction: the old slug now redirects to the
+// entity, and a redirect for the new slug (the entity taking an old slug back) is dropped
+func recordSlugChange(tx *gorm.DB, entityType string, entityID uuid.UUID, oldSlug, newSlug string) error {
+	if oldSlug == "" || oldSlug == newSlug {
+		return nil
+	}
+
+	if err := tx.Where("entity_type = ? AND old_slug = ?", entityType, newSlug).
+		Delete(&models.SlugRedirect{}).Error; err != nil {
+		return err
+	}
+
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "entity_type"}, {Name: "old_slug"}},
+		DoUpdates: clause.AssignmentColumns([]string{"entity_id", "created_at"}),
+	}).Create(&models.SlugRedirect{
+		EntityType: entityType,
+		OldSlug:    oldSlug,
+		EntityID:   entityID,
+	}).Error
+}
@@ -0,0 +1,99 @@
+package sql
+
+import (
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type stockAlertRepository struct {
+	DB *gorm.DB
+}
+
+func NewStockAlertRepository(db *gorm.DB) interfaces.StockAlertRepository {
+	return &stockAlertRepository{
+		DB: db,
+	}
+}
+
+// ClaimAlerts marks the given products (and their sizes) that just went low or sold out and
+// haven't been alerted yet, and returns them. The conditional UPDATE makes each alert fire once,
+// even when orders for the same product commit at the same time.
+func (r *stockAlertRepository) ClaimAlerts(productIDs []uuid.UUID, now time.Time) ([]interfaces.StockAlert, error) {
+	if len(productIDs) == 0 {
+		return nil, nil
+	}
+
+	var alerts []interfaces.StockAlert
+
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		var products []models.Product
+		if err := tx.Model(&products).
+			Clauses(clause.Returning{}).
+			Where("id IN ? AND low_stock_alerted_at IS NULL AND stock_count <= low_stock_threshold", productIDs).
+			Update("low_stock_alerted_at", now).Error; err != nil {
+			return err
+		}
+		for _, p := range products {
+			alerts = append(alerts, interfaces.StockAlert{
+				ProductID:  p.ID,
+				Name:       p.Name,
+				StockCount: p.StockCount,
+				Threshold:  p.LowStockThreshold,
+			})
+		}
+
+		var variants []models.ProductVariant
+		if err := tx.Model(&variants).
+			Clauses(clause.Returning{}).
+			Where("product_id IN ? AND is_active = ? AND sold_out_alerted_at IS NULL AND stock_count <= 0", productIDs, true).
+			Update("sold_out_alerted_at", now).Error; err != nil {
+			return err
+		}
+		if len(variants) == 0 {
+			return nil
+		}
+
+		names := make(map[uuid.UUID]string)
+		var owners []models.Product
+		if err := tx.Unscoped().Select("id", "name").Where("id IN ?", productIDs).Find(&owners).Error; err != nil {
+			return err
+		}
+		for _, p := range owners {
+			names[p.ID] = p.Name
+		}
+
+		for _, v := range variants {
+			alerts = append(alerts, interfaces.StockAlert{
+				ProductID:  v.ProductID,
+				Name:       names[v.ProductID],
+				Size:       v.Size,
+				StockCount: v.StockCount,
+			})
+		}
+		return nil
+	})
+
+	return alerts, err
+}
+
+// FindLowStock lists products at or below their threshold, or with a sold-out size,
+// lowest stock first
+func (r *stockAlertRepository) FindLowStock() ([]models.Product, error) {
+	var products []models.Product
+
+	err := r.DB.
+		Preload("Variants", "is_active = ?", true).
+		Preload("Category").
+		Where(`products.stock_count <= products.low_stock_threshold OR EXISTS (
+			SELECT 1 FROM product_variants v
+			WHERE v.product_id = products.id AND v.is_active = TRUE AND v.deleted_at IS NULL AND v.stock_count <= 0)`).
+		Order("products.stock_count ASC, products.name ASC").
+		Find(&products).Error
+
+	return products, err
+}
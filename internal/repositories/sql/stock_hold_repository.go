@@ -0,0 +1,190 @@
+package sql
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type stockHoldRepository struct {
+	DB *gorm.DB
+}
+
+func NewStockHoldRepository(db *gorm.DB) interfaces.StockHoldRepository {
+	return &stockHoldRepository{
+		DB: db,
+	}
+}
+
+// HoldCart replaces the user's holds with one per cart line, if every line still fits in
+// the stock left after other customers' active holds. While the user still has an active
+// hold the new ones keep its expiry, so calling checkout again can't stretch the window.
+func (r *stockHoldRepository) HoldCart(userID uuid.UUID, items []models.CartItem, expiresAt, now time.Time) ([]models.StockHold, error) {
+	var holds []models.StockHold
+
+	// lock products in a fixed order so concurrent checkouts can't deadlock
+	sorted := make([]models.CartItem, len(items))
+	copy(sorted, items)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ProductID.String() < sorted[j].ProductID.String()
+	})
+
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		var current struct {
+			ExpiresAt *time.Time
+		}
+		if err := tx.Model(&models.StockHold{}).
+			Select("MIN(expires_at) AS expires_at").
+			Where("user_id = ? AND expires_at > ?", userID, now).
+			Scan(&current).Error; err != nil {
+			return err
+		}
+		expiresAt = holdExpiry(current.ExpiresAt, expiresAt)
+
+		if err := tx.Where("user_id = ?", userID).Delete(&models.StockHold{}).Error; err != nil {
+			return err
+		}
+
+		productQty := make(map[uuid.UUID]int)
+		for _, ci := range sorted {
+			productQty[ci.ProductID] += ci.Quantity
+		}
+
+		for _, ci := range sorted {
+			var product models.Product
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("id = ?", ci.ProductID).
+				First(&product).Error; err != nil {
+				return err
+			}
+			if err := checkProductAvailable(&product, now); err != nil {
+				return err
+			}
+			if err := checkNoOpenRaffle(tx, &product); err != nil {
+				return err
+			}
+
+			variant, err := resolveOrderVariant(tx, &product, ci.VariantID)
+			if err != nil {
+				return err
+			}
+
+			if err := checkAvailableStock(tx, userID, &product, variant, productQty[ci.ProductID], ci.Quantity, now); err != nil {
+				return err
+			}
+
+			holds = append(holds, models.StockHold{
+				UserID:    userID,
+				ProductID: ci.ProductID,
+				VariantID: ci.VariantID,
+				Quantity:  ci.Quantity,
+				ExpiresAt: expiresAt,
+			})
+		}
+
+		if len(holds) == 0 {
+			return nil
+		}
+		return tx.Create(&holds).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return holds, nil
+}
+
+// DeleteExpired clears holds that no longer count
+func (r *stockHoldRepository) DeleteExpired(now time.Time) (int64, error) {
+	res := r.DB.Where("expires_at <= ?", now).Delete(&models.StockHold{})
+	return res.RowsAffected, res.Error
+}
+
+// checkAvailableStock makes sure productQty units of the product (variantQty of the size)
+// fit in the stock other customers haven't got on hold. The user's own holds don't count
+// against them: that's what checkout reserved.
+func checkAvailableStock(tx *gorm.DB, userID uuid.UUID, product *models.Product, variant *models.ProductVariant, productQty, variantQty int, now time.Time) error {
+	held, err := heldByOthers(tx, userID, product.ID, nil, now)
+	if err != nil {
+		return err
+	}
+	if available := availableAfterHolds(product.StockCount, held); productQty > available {
+		return fmt.Errorf("insufficient stock for product %s: only %d available", product.Name, available)
+	}
+
+	if variant != nil {
+		held, err := heldByOthers(tx, userID, product.ID, &variant.ID, now)
+		if err != nil {
+			return err
+		}
+		if available := availableAfterHolds(variant.StockCount, held); variantQty > available {
+			return fmt.Errorf("insufficient stock for product %s size %s: only %d available", product.Name, variant.Size, available)
+		}
+	}
+
+	return nil
+}
+
+// holdExpiry is when refreshed holds expire: a checkout that is still running keeps its
+// earliest expiry, otherwise the requested one starts a new window
+func holdExpiry(current *time.Time, requested time.Time) time.Time {
+	if current != nil && current.Before(requested) {
+		return *current
+	}
+	return requested
+}
+
+// availableAfterHolds is the stock left once other customers' holds are taken off, never below zero
+func availableAfterHolds(stock, held int) int {
+	return max(stock-held, 0)
+}
+
+// heldByOthers sums other users' active holds on a product (or one of its sizes)
+func heldByOthers(tx *gorm.DB, userID, productID uuid.UUID, variantID *uuid.UUID, now time.Time) (int, error) {
+	query := tx.Model(&models.StockHold{}).
+		Where("product_id = ? AND user_id <> ? AND expires_at > ?", productID, userID, now)
+	if variantID != nil {
+		query = query.Where("variant_id = ?", *variantID)
+	}
+
+	var total int
+	err := query.Select("COALESCE(SUM(quantity), 0)").Scan(&total).Error
+	return total, err
+}
+
+// activeHolds sums every active hold per product and per variant, for the available stock shown to customers
+func activeHolds(db *gorm.DB, productIDs []uuid.UUID, now time.Time) (map[uuid.UUID]int, map[uuid.UUID]int, error) {
+	byProduct := make(map[uuid.UUID]int)
+	byVariant := make(map[uuid.UUID]int)
+	if len(productIDs) == 0 {
+		return byProduct, byVariant, nil
+	}
+
+	var rows []struct {
+		ProductID uuid.UUID
+		VariantID *uuid.UUID
+		Quantity  int
+	}
+	err := db.Model(&models.StockHold{}).
+		Select("product_id, variant_id, SUM(quantity) AS quantity").
+		Where("product_id IN ? AND expires_at > ?", productIDs, now).
+		Group("product_id, variant_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, row := range rows {
+		byProduct[row.ProductID] += row.Quantity
+		if row.VariantID != nil {
+			byVariant[*row.VariantID] += row.Quantity
+		}
+	}
+	return byProduct, byVariant, nil
+}
@@ -0,0 +1,53 @@
+package sql
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHoldExpiry(t *testing.T) {
+	now := time.Date(2025, 11, 28, 10, 0, 0, 0, time.UTC)
+	requested := now.Add(10 * time.Minute)
+	running := now.Add(3 * time.Minute)
+	later := now.Add(20 * time.Minute)
+
+	tests := []struct {
+		name    string
+		current *time.Time
+		want    time.Time
+	}{
+		{name: "no active hold starts a window", current: nil, want: requested},
+		{name: "a running checkout keeps its expiry", current: &running, want: running},
+		{name: "never extends past the requested expiry", current: &later, want: requested},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := holdExpiry(tt.current, requested); !got.Equal(tt.want) {
+				t.Fatalf("holdExpiry() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAvailableAfterHolds(t *testing.T) {
+	tests := []struct {
+		name        string
+		stock, held int
+		want        int
+	}{
+		{name: "nothing held", stock: 5, held: 0, want: 5},
+		{name: "some held", stock: 5, held: 3, want: 2},
+		{name: "all held", stock: 5, held: 5, want: 0},
+		// stock lowered by an admin below what is already on hold
+		{name: "more held than in stock", stock: 2, held: 4, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := availableAfterHolds(tt.stock, tt.held); got != tt.want {
+				t.Fatalf("availableAfterHolds(%d, %d) = %d, want %d", tt.stock, tt.held, got, tt.want)
+			}
+		})
+	}
+}
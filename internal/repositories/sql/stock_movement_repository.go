@@ -0,0 +1,164 @@
+package sql
+
+import (
+	"errors"
+	"fmt"
+
+	constent "github.com/akhilnasimk/SS_backend/internal/const"
+	"github.com/akhilnasimk/SS_backend/internal/enums"
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type stockMovementRepository struct {
+	DB *gorm.DB
+}
+
+func NewStockMovementRepository(db *gorm.DB) interfaces.StockMovementRepository {
+	return &stockMovementRepository{
+		DB: db,
+	}
+}
+
+// FindByProduct pages a product's ledger, newest first
+func (r *stockMovementRepository) FindByProduct(productID uuid.UUID, limit, offset int) ([]models.StockMovement, int64, error) {
+	var movements []models.StockMovement
+	var total int64
+
+	query := r.DB.Model(&models.StockMovement{}).Where("product_id = ?", productID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&movements).Error
+
+	return movements, total, err
+}
+
+// Adjust changes stock by hand (corrections, returns). Products with sizes are adjusted
+// through a variant and their stock re-synced, like the admin variant edit.
+func (r *stockMovementRepository) Adjust(productID uuid.UUID, variantID *uuid.UUID, delta int, reason enums.StockMovementReason, note string, actorID uuid.UUID) (*models.StockMovement, error) {
+	var movement models.StockMovement
+
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		var product models.Product
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", productID).
+			First(&product).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("product not found")
+			}
+			return err
+		}
+
+		movement = models.StockMovement{
+			ProductID:     productID,
+			Reason:        reason,
+			ReferenceType: constent.StockRefProduct,
+			ReferenceID:   &product.ID,
+			ActorID:       optionalActor(actorID),
+			Note:          note,
+		}
+
+		if variantID == nil {
+			needsVariant, err := hasActiveVariants(tx, productID)
+			if err != nil {
+				return err
+			}
+			if needsVariant {
+				return errors.New("please select a size")
+			}
+
+			if product.StockCount+delta < 0 {
+				return fmt.Errorf("invalid adjustment: stock would drop below zero (current %d)", product.StockCount)
+			}
+			if err := tx.Model(&product).Update("stock_count", product.StockCount+delta).Error; err != nil {
+				return err
+			}
+
+			movement.Delta = delta
+			return recordStockMovement(tx, &movement)
+		}
+
+		variant, err := lockVariant(tx, productID, *variantID)
+		if err != nil {
+			return err
+		}
+		if variant.StockCount+delta < 0 {
+			return fmt.Errorf("invalid adjustment: size stock would drop below zero (current %d)", variant.StockCount)
+		}
+		if err := adjustVariantStock(tx, variant.ID, delta); err != nil {
+			return err
+		}
+
+		movement.VariantID = &variant.ID
+		movement.ReferenceType = constent.StockRefVariant
+		movement.ReferenceID = &variant.ID
+		return syncProductStockLogged(tx, productID, &movement)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &movement, nil
+}
+
+// Reconcile lists every live product with its stock_count next to the ledger total
+func (r *stockMovementRepository) Reconcile() ([]interfaces.StockReconciliation, error) {
+	var rows []interfaces.StockReconciliation
+
+	err := r.DB.Model(&models.Product{}).
+		Select(`products.id AS product_id, products.name AS product_name, products.stock_count,
+			COALESCE(SUM(stock_movements.delta), 0) AS ledger_stock,
+			COUNT(stock_movements.id) AS movements`).
+		Joins("LEFT JOIN stock_movements ON stock_movements.product_id = products.id").
+		Group("products.id").
+		Order("products.name ASC, products.id ASC").
+		Scan(&rows).Error
+
+	return rows, err
+}
+
+// recordStockMovement appends a ledger line; call it inside the transaction that changed the stock
+func recordStockMovement(tx *gorm.DB, movement *models.StockMovement) error {
+	if movement.Delta == 0 {
+		return nil
+	}
+	return tx.Create(movement).Error
+}
+
+// syncProductStockLogged re-syncs the product stock from its variants (syncProductStock)
+// and records the resulting change in the ledger
+func syncProductStockLogged(tx *gorm.DB, productID uuid.UUID, movement *models.StockMovement) error {
+	var before, after int
+	if err := tx.Model(&models.Product{}).Where("id = ?", productID).Select("stock_count").Scan(&before).Error; err != nil {
+		return err
+	}
+
+	if err := syncProductStock(tx, productID); err != nil {
+		return err
+	}
+
+	if err := tx.Model(&models.Product{}).Where("id = ?", productID).Select("stock_count").Scan(&after).Error; err != nil {
+		return err
+	}
+
+	movement.ProductID = productID
+	movement.Delta = after - before
+	return recordStockMovement(tx, movement)
+}
+
+// optionalActor stores uuid.Nil (system jobs) as NULL
+func optionalActor(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	return &id
+}
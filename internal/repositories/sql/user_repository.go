@@ -21,8 +21,9 @@ func NewUserReposetory(db gorm.DB) interfaces.UserRepository {
 	}
 }
 
-func (r *userRepository) CreateUser(user models.User) error {
-	resp := r.DB.Create(&user)
+// CreateUser inserts the user and fills in the generated ID
+func (r *userRepository) CreateUser(user *models.User) error {
+	resp := r.DB.Create(user)
 	if resp.Error != nil {
 		return resp.Error
 	}
@@ -108,6 +109,25 @@ func (r *userRepository) GetAllUsersPaginated(limit, offset int) ([]models.User,
 	return users, total, nil
 }
 
+// GetUsersByCursor pages users by (created_at, id) without OFFSET or COUNT(*)
+func (r *userRepository) GetUsersByCursor(limit int, after *interfaces.Keyset) ([]models.User, error) {
+	var users []models.User
+
+	query := r.DB.Select("id, user_name, email, created_at,user_role,is_admin,is_blocked")
+	if after != nil {
+		query = query.Where("(created_at, id) < (?, ?)", after.CreatedAt, after.ID)
+	}
+
+	if err := query.
+		Order("created_at desc, id desc").
+		Limit(limit).
+		Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
 // we use transaction because if not both happend it become very bad that blocked user can still refresh tokens
 func (r *userRepository) ToggleBlock(id uuid.UUID) error {
 	return r.DB.Transaction(func(tx *gorm.DB) error {
@@ -149,3 +169,12 @@ func (r *userRepository) ToggleBlock(id uuid.UUID) error {
 		return nil
 	})
 }
+
+// FindAdminEmails lists where admin notifications go (blocked accounts excluded)
+func (r *userRepository) FindAdminEmails() ([]string, error) {
+	var emails []string
+	err := r.DB.Model(&models.User{}).
+		Where("user_role = ? AND is_blocked = ?", "admin", false).
+		Pluck("email", &emails).Error
+	return emails, err
+}
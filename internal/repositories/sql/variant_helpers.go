@@ -0,0 +1,50 @@
+package sql
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// lockVariant loads the variant with a row lock and makes sure it belongs to the product
+func lockVariant(tx *gorm.DB, productID, variantID uuid.UUID) (*models.ProductVariant, error) {
+	var variant models.ProductVariant
+
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND product_id = ?", variantID, productID).
+		First(&variant).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("variant not found for product %s", productID)
+		}
+		return nil, err
+	}
+
+	if !variant.IsActive {
+		return nil, fmt.Errorf("variant %s is not available", variant.SKU)
+	}
+
+	return &variant, nil
+}
+
+// hasActiveVariants tells if a product can only be bought by picking a variant
+func hasActiveVariants(tx *gorm.DB, productID uuid.UUID) (bool, error) {
+	var count int64
+
+	err := tx.Model(&models.ProductVariant{}).
+		Where("product_id = ? AND is_active = ?", productID, true).
+		Count(&count).Error
+
+	return count > 0, err
+}
+
+// adjustVariantStock adds delta (negative for sales) to a variant's stock
+func adjustVariantStock(tx *gorm.DB, variantID uuid.UUID, delta int) error {
+	return tx.Model(&models.ProductVariant{}).
+		Where("id = ?", variantID).
+		Update("stock_count", gorm.Expr("stock_count + ?", delta)).Error
+}
@@ -26,9 +26,7 @@ func (r *wishlistRepository) FindAllWishItems(userID uuid.UUID) ([]models.Wishli
 
 	err := r.DB.
 		Preload("Product", "deleted_at IS NULL AND is_active = ?", true).
-		Preload("Product.Images", func(db *gorm.DB) *gorm.DB {
-			return db.Where("deleted_at IS NULL").Order("priority ASC")
-		}).
+		Preload("Product.Images", orderedImages).
 		Where("user_id = ?", userID).
 		Order("created_at DESC").
 		Find(&wishlist).Error
@@ -60,8 +58,8 @@ func (r *wishlistRepository) DeleteWishlist(wishlistID uuid.UUID) error {
 	return nil
 }
 
-// ToggleWishlist adds or removes a product from user's wishlist
-func (r *wishlistRepository) ToggleWishlist(userID, productID uuid.UUID) (string, *models.Wishlist, error) {
+// ToggleWishlist adds or removes a product from user's wishlist, price is the current product price
+func (r *wishlistRepository) ToggleWishlist(userID, productID uuid.UUID, price int64) (string, *models.Wishlist, error) {
 	var existing models.Wishlist
 
 	err := r.DB.
@@ -82,9 +80,10 @@ func (r *wishlistRepository) ToggleWishlist(userID, productID uuid.UUID) (string
 
 	// If not exists → add it
 	newItem := models.Wishlist{
-		UserID:    userID,
-		ProductID: productID,
-		CreatedAt: time.Now(),
+		UserID:     userID,
+		ProductID:  productID,
+		PriceAtAdd: price,
+		CreatedAt:  time.Now(),
 	}
 
 	if createErr := r.DB.Create(&newItem).Error; createErr != nil {
@@ -96,7 +95,7 @@ func (r *wishlistRepository) ToggleWishlist(userID, productID uuid.UUID) (string
 	if preloadErr := r.DB.
 		Preload("User").
 		Preload("Product").
-		Preload("Product.Images").
+		Preload("Product.Images", orderedImages).
 		Where("id = ?", newItem.ID).
 		First(&fullItem).Error; preloadErr != nil {
 		return "added", &newItem, nil // fallback
@@ -117,3 +116,25 @@ func (r *wishlistRepository) FindByUserAndProduct(userID, productID uuid.UUID) (
 	}
 	return &item, nil
 }
+
+// ClaimPriceDropRecipients records price as the last notified price on every wishlist row of
+// the product that hasn't been told about this price or a lower one yet, and returns those
+// customers. Blocked accounts and opt-outs are skipped. A price going back up and down again
+// doesn't mail the same people twice.
+func (r *wishlistRepository) ClaimPriceDropRecipients(productID uuid.UUID, price int64) ([]interfaces.PriceDropRecipient, error) {
+	var recipients []interfaces.PriceDropRecipient
+
+	err := r.DB.Raw(`
+		UPDATE wishlists w
+		SET last_notified_price = ?
+		FROM users u
+		WHERE u.id = w.user_id AND u.deleted_at IS NULL
+		  AND u.price_drop_alerts = TRUE AND u.is_blocked = FALSE
+		  AND w.product_id = ?
+		  AND (w.last_notified_price IS NULL OR w.last_notified_price > ?)
+		RETURNING u.email, u.user_name, w.price_at_add`,
+		price, productID, price).
+		Scan(&recipients).Error
+
+	return recipients, err
+}
@@ -13,9 +13,12 @@ func AuthRoutes(rg *gin.RouterGroup) {
 	// ---------------------
 	// Repository Layer
 	// ---------------------
-	userRepo := sql.NewUserReposetory(*config.DB)  // User repository
-	tokenRepo := sql.NewTokenRepository(config.DB) // Refresh token repository
-	otpRepo := sql.NewOtpRepository(config.DB)     // OTP repository
+	userRepo := sql.NewUserReposetory(*config.DB)                    // User repository
+	tokenRepo := sql.NewTokenRepository(config.DB)                   // Refresh token repository
+	otpRepo := sql.NewOtpRepository(config.DB)                       // OTP repository
+	recentlyViewedRepo := sql.NewRecentlyViewedRepository(config.DB) // Guest history merged on login
+	cartRepo := sql.NewcartRepository(*config.DB)                    // Guest cart merged on login
+	productRepo := sql.NewProductsRepository(*config.DB)             // Needed by the cart service
 
 	// ---------------------
 	// Service Layer
@@ -23,11 +26,13 @@ func AuthRoutes(rg *gin.RouterGroup) {
 	authService := services.NewAuthService(userRepo, tokenRepo) // Handles register/login/refresh
 	emailService := services.NewEmailService()                  // Used by OTP service
 	otpService := services.NewOtpService(otpRepo, emailService) // OTP generation/validation
+	recentlyViewedService := services.NewRecentlyViewedService(recentlyViewedRepo)
+	cartService := services.NewCartService(cartRepo, productRepo)
 
 	// ---------------------
 	// Controller Layer
 	// ---------------------
-	authController := controllers.NewAuthController(authService, otpService)
+	authController := controllers.NewAuthController(authService, otpService, recentlyViewedService, cartService)
 
 	// ---------------------
 	// Public Auth Routes
@@ -1,6 +1,8 @@
 package routes
 
 import (
+	"time"
+
 	"github.com/akhilnasimk/SS_backend/internal/config"
 	"github.com/akhilnasimk/SS_backend/internal/controllers"
 	"github.com/akhilnasimk/SS_backend/internal/middlewares"
@@ -9,6 +11,9 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// how often stale guest carts are deleted
+const guestCartExpiryInterval = time.Hour
+
 func RegisterCartRoutes(rg *gin.RouterGroup) {
 
 	// ---------------------
@@ -21,6 +26,7 @@ func RegisterCartRoutes(rg *gin.RouterGroup) {
 	// Service Layer
 	// ---------------------
 	cartService := services.NewCartService(cartRepo, productRepo) // Handles cart logic (add, update, delete)
+	cartService.StartGuestCartExpiry(guestCartExpiryInterval)     // Drops guest carts after GUEST_CART_TTL_DAYS
 
 	// ---------------------
 	// Controller Layer
@@ -28,11 +34,11 @@ func RegisterCartRoutes(rg *gin.RouterGroup) {
 	cartController := controllers.NewCartController(cartService)
 
 	// ---------------------
-	// Cart Routes (JWT Protected)
+	// Cart Routes (logged-in user, or guest by cookie)
 	// ---------------------
-	rg.Use(middlewares.AuthorizeMiddleware()) // Ensure user is authenticated
+	rg.Use(middlewares.OptionalAuth(), middlewares.RefreshBeforeGuest(), middlewares.GuestSession()) // Guests get a guest_id cookie, merged into the account on login
 	{
-		rg.GET("/", cartController.GetUserCart)                  // Get all cart items for current user
+		rg.GET("/", cartController.GetUserCart)                  // Get all cart items for current user or guest
 		rg.POST("/:product_id", cartController.AddToCart)        // Add a product to the cart
 		rg.PATCH("/:item_id", cartController.UpdateCount)        // Increment/decrement quantity of a cart item
 		rg.DELETE("/:cartItemId", cartController.DeleteCartItem) // Remove a product from the cart
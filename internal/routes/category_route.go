@@ -0,0 +1,39 @@
+package routes
+
+import (
+	"github.com/akhilnasimk/SS_backend/internal/config"
+	"github.com/akhilnasimk/SS_backend/internal/controllers"
+	"github.com/akhilnasimk/SS_backend/internal/middlewares"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/sql"
+	"github.com/akhilnasimk/SS_backend/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterCategoryRoutes sets up the category tree and its admin management
+func RegisterCategoryRoutes(rg *gin.RouterGroup) {
+	// Repository
+	categoryRepo := sql.NewCategoryRepository(config.DB)
+	redirectRepo := sql.NewSlugRedirectRepository(config.DB)
+	// Service
+	categoryService := services.NewCategoryService(categoryRepo, redirectRepo)
+	// Controller
+	categoryController := controllers.NewCategoryController(categoryService)
+
+	// ---------------------
+	// Public Routes
+	// ---------------------
+	rg.GET("/", categoryController.GetCategoryTree)             // Nested category tree
+	rg.GET("/:id", categoryController.GetCategory)              // Single category
+	rg.GET("/slug/:slug", categoryController.GetCategoryBySlug) // Single category by slug, old slugs redirect
+
+	// ---------------------
+	// Admin Routes (JWT + Admin Role)
+	// ---------------------
+	admin := rg.Group("/admin")
+	admin.Use(middlewares.AuthorizeMiddleware(), middlewares.AdminAuth())
+	{
+		admin.POST("", categoryController.CreateCategory)       // Add category (optionally under a parent)
+		admin.PUT("/:id", categoryController.UpdateCategory)    // Rename / move / reorder
+		admin.DELETE("/:id", categoryController.DeleteCategory) // Delete, ?reassign_to=<id> moves its products
+	}
+}
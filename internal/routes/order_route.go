@@ -1,6 +1,8 @@
 package routes
 
 import (
+	"time"
+
 	"github.com/akhilnasimk/SS_backend/internal/config"
 	"github.com/akhilnasimk/SS_backend/internal/controllers"
 	"github.com/akhilnasimk/SS_backend/internal/middlewares"
@@ -9,13 +11,21 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// how often expired checkout holds are deleted
+const checkoutHoldCleanupInterval = time.Minute
+
 func RegisterOrderRoutes(rg *gin.RouterGroup) {
 	//repositories
 	OrderRepo := sql.NewOrderRepository(*config.DB)
 	Cartrepo := sql.NewcartRepository(*config.DB)
+	HoldRepo := sql.NewStockHoldRepository(config.DB)
+	AlertRepo := sql.NewStockAlertRepository(config.DB)
+	UserRepo := sql.NewUserReposetory(*config.DB)
 
 	//services
-	OrderService := services.NewOrderService(OrderRepo, Cartrepo)
+	AlertService := services.NewStockAlertService(AlertRepo, UserRepo, services.NewEmailService())
+	OrderService := services.NewOrderService(OrderRepo, Cartrepo, HoldRepo, AlertService)
+	OrderService.StartHoldCleanup(checkoutHoldCleanupInterval)
 
 	//controller
 	OrderController := controllers.NewOrderController(OrderService)
@@ -25,6 +35,9 @@ func RegisterOrderRoutes(rg *gin.RouterGroup) {
 		// Get all orders for logged-in user
 		rg.GET("/", OrderController.GetAllOrders)
 
+		// Start checkout: hold the cart's stock for a few minutes
+		rg.POST("/checkout", OrderController.StartCheckout)
+
 		// Create orders
 		rg.POST("/single/:product_id", OrderController.AddSingleItemOrder)
 		rg.POST("/cart/:cart_id", OrderController.AddCartOrder)
@@ -6,34 +6,50 @@ import (
 	"github.com/akhilnasimk/SS_backend/internal/middlewares"
 	"github.com/akhilnasimk/SS_backend/internal/repositories/sql"
 	"github.com/akhilnasimk/SS_backend/internal/services"
+	"github.com/akhilnasimk/SS_backend/utils/media"
 	"github.com/gin-gonic/gin"
 )
 
 // RegisterProductRoutes sets up routes for products
-func RegisterProductRoutes(rg *gin.RouterGroup) {
+func RegisterProductRoutes(rg *gin.RouterGroup, store media.MediaStore) {
 
 	// ---------------------
 	// Repository Layer
 	// ---------------------
-	productRepo := sql.NewProductsRepository(*config.DB) // Product repository
+	productRepo := sql.NewProductsRepository(*config.DB)             // Product repository
+	recentlyViewedRepo := sql.NewRecentlyViewedRepository(config.DB) // Recently viewed history
+	redirectRepo := sql.NewSlugRedirectRepository(config.DB)         // Old slugs → current product
+	wishlistRepo := sql.NewWishlistRepo(config.DB)                   // Who to tell about price drops
 
 	// ---------------------
 	// Service Layer
 	// ---------------------
-	productService := services.NewProductsService(productRepo) // Product business logic
+	wishlistService := services.NewWishlistService(wishlistRepo, productRepo, services.NewEmailService()) // Wishlist price-drop emails
+	productService := services.NewProductsService(productRepo, redirectRepo, store, wishlistService)      // Product business logic
+	recentlyViewedService := services.NewRecentlyViewedService(recentlyViewedRepo)                        // Records product page views
 
 	// ---------------------
 	// Controller Layer
 	// ---------------------
-	productController := controllers.NewProductController(productService)
+	productController := controllers.NewProductController(productService, recentlyViewedService)
+	recentlyViewedController := controllers.NewRecentlyViewedController(recentlyViewedService)
 
 	// ---------------------
 	// Public Product Routes (Optional Auth)
 	// ---------------------
 	// OptionalAuth allows both logged-in users and guests to view products
 	rg.GET("/", middlewares.OptionalAuth(), productController.GetAllProducts) // List all products
-	rg.GET("/:id", productController.GetProductById)                          // Get product details by ID
 	rg.GET("/categories", productController.GetAllCategory)                   // List all categories
+	rg.GET("/upcoming", productController.GetUpcomingDrops)                   // Unreleased drops with countdowns
+
+	// Product page views are recorded for customers and for guests (guest_id cookie)
+	viewer := rg.Group("")
+	viewer.Use(middlewares.OptionalAuth(), middlewares.GuestSession())
+	{
+		viewer.GET("/:id", productController.GetProductById)                       // Get product details by ID
+		viewer.GET("/slug/:slug", productController.GetProductBySlug)              // Get product details by slug, old slugs redirect
+		viewer.GET("/recently-viewed", recentlyViewedController.GetRecentlyViewed) // Guest or user history, newest first
+	}
 
 	// ---------------------
 	// Admin Product Routes (JWT + Admin Role)
@@ -45,6 +61,24 @@ func RegisterProductRoutes(rg *gin.RouterGroup) {
 		admin.PUT("/:id", productController.UpdateProduct)                          // Update product details
 		admin.PATCH("/:id/toggle-availability", productController.ToggleProductAvailability) // Enable/disable product visibility
 		admin.DELETE("/:id", productController.DeleteProduct)                        // Delete product
-		admin.PATCH("/undelete/:id")                                                // Undelete soft-deleted product (if implemented)
+		admin.PATCH("/undelete/:id", productController.RestoreProduct)              // Undelete soft-deleted product
+
+		// Images: display order, primary image, alt text
+		admin.PUT("/:id/images/order", productController.ReorderImages)                  // Body: {"image_ids": [...]} in display order
+		admin.PATCH("/:id/images/:image_id/primary", productController.SetPrimaryImage)  // Make an image the primary one
+		admin.PATCH("/:id/images/:image_id", productController.UpdateImageAltText)       // Body: {"alt_text": "..."}
+
+		// Bulk CSV import / export
+		admin.POST("/import", productController.ImportProducts) // ?dry_run=false to apply
+		admin.GET("/export", productController.ExportProducts)  // Download the catalog as CSV
+
+		// Trash (soft-deleted products)
+		admin.GET("/trash", productController.GetTrash)            // Browse deleted products
+		admin.DELETE("/trash", productController.PurgeTrash)       // Hard-delete products past the retention period
+
+		// Size / colorway variants
+		admin.GET("/:id/variants", productController.GetVariants)                 // List all variants of a product
+		admin.POST("/:id/variants", productController.AddVariant)                 // Add a variant (SKU)
+		admin.PUT("/:id/variants/:variant_id", productController.UpdateVariant)   // Update a variant
 	}
 }
@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"github.com/akhilnasimk/SS_backend/internal/config"
+	"github.com/akhilnasimk/SS_backend/internal/controllers"
+	"github.com/akhilnasimk/SS_backend/internal/middlewares"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/sql"
+	"github.com/akhilnasimk/SS_backend/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterQuestionRoutes adds the product Q&A thread under the products group (/products/:id/questions)
+func RegisterQuestionRoutes(rg *gin.RouterGroup) {
+	// Repositories
+	questionRepo := sql.NewQuestionRepository(config.DB)
+	orderRepo := sql.NewOrderRepository(*config.DB)
+	productRepo := sql.NewProductsRepository(*config.DB)
+
+	// Services
+	emailService := services.NewEmailService()
+	questionService := services.NewQuestionService(questionRepo, orderRepo, productRepo, emailService)
+
+	// Controller
+	questionController := controllers.NewQuestionController(questionService)
+
+	// Public
+	rg.GET("/:id/questions", questionController.GetProductQuestions) // Questions with their answers
+
+	// Customers ask
+	customer := rg.Group("/:id/questions")
+	customer.Use(middlewares.AuthorizeMiddleware(), middlewares.CustomerAuth())
+	{
+		customer.POST("", questionController.AskQuestion) // Ask a question
+	}
+
+	// Admins or verified buyers answer (buyer check in the service)
+	answer := rg.Group("/:id/questions/:question_id/answers")
+	answer.Use(middlewares.AuthorizeMiddleware())
+	{
+		answer.POST("", questionController.AnswerQuestion) // Answer a question, the asker is emailed
+	}
+}
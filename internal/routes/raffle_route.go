@@ -0,0 +1,52 @@
+package routes
+
+import (
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/config"
+	"github.com/akhilnasimk/SS_backend/internal/controllers"
+	"github.com/akhilnasimk/SS_backend/internal/middlewares"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/sql"
+	"github.com/akhilnasimk/SS_backend/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// how often lapsed winner reservations are returned to stock
+const raffleExpiryInterval = 5 * time.Minute
+
+// RegisterRaffleRoutes adds raffle entry, draw and winner purchase, and starts the reservation expiry
+func RegisterRaffleRoutes(rg *gin.RouterGroup) {
+	// Repositories
+	raffleRepo := sql.NewRaffleRepository(config.DB)
+	productRepo := sql.NewProductsRepository(*config.DB)
+
+	// Services
+	emailService := services.NewEmailService()
+	raffleService := services.NewRaffleService(raffleRepo, productRepo, emailService)
+	raffleService.StartReservationExpiry(raffleExpiryInterval)
+
+	// Controller
+	raffleController := controllers.NewRaffleController(raffleService)
+
+	// Public
+	rg.GET("", raffleController.GetOpenRaffles) // Open and upcoming raffles
+	rg.GET("/:id", raffleController.GetRaffle)  // Raffle details, seed hash until drawn, then the seed
+
+	// Customers
+	customer := rg.Group("/:id")
+	customer.Use(middlewares.AuthorizeMiddleware(), middlewares.CustomerAuth())
+	{
+		customer.POST("/entries", raffleController.EnterRaffle)          // Enter (one entry per account)
+		customer.GET("/entries/me", raffleController.GetMyEntry)         // My entry and result
+		customer.POST("/purchase", raffleController.PurchaseReservation) // Winners buy their reserved unit
+	}
+
+	// Admin
+	admin := rg.Group("/admin")
+	admin.Use(middlewares.AuthorizeMiddleware(), middlewares.AdminAuth())
+	{
+		admin.POST("", raffleController.CreateRaffle)                // Create a raffle for a product
+		admin.POST("/:id/draw", raffleController.DrawRaffle)         // Draw with the committed seed after close
+		admin.GET("/:id/entries", raffleController.GetRaffleEntries) // Audit: entries, tickets, positions
+	}
+}
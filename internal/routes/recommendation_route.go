@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/config"
+	"github.com/akhilnasimk/SS_backend/internal/controllers"
+	"github.com/akhilnasimk/SS_backend/internal/middlewares"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/sql"
+	"github.com/akhilnasimk/SS_backend/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRecommendationRoutes adds "frequently bought together" under the products group
+// and starts the periodic co-purchase refresh
+func RegisterRecommendationRoutes(rg *gin.RouterGroup) {
+	// Repositories
+	recommendationRepo := sql.NewRecommendationRepository(config.DB)
+	productRepo := sql.NewProductsRepository(*config.DB)
+
+	// Service
+	recommendationService := services.NewRecommendationService(recommendationRepo, productRepo)
+	recommendationService.StartCoPurchaseRefresher(time.Duration(config.AppConfig.CoPurchaseRefreshMins) * time.Minute)
+
+	// Controller
+	recommendationController := controllers.NewRecommendationController(recommendationService)
+
+	// Public
+	rg.GET("/:id/recommendations", recommendationController.GetFrequentlyBoughtTogether) // Co-purchased products, most frequent first
+
+	// Admin
+	admin := rg.Group("/admin")
+	admin.Use(middlewares.AuthorizeMiddleware(), middlewares.AdminAuth())
+	{
+		admin.POST("/recommendations/refresh", recommendationController.RefreshRecommendations) // Rebuild now
+	}
+}
@@ -0,0 +1,35 @@
+package routes
+
+import (
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/config"
+	"github.com/akhilnasimk/SS_backend/internal/controllers"
+	"github.com/akhilnasimk/SS_backend/internal/middlewares"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/sql"
+	"github.com/akhilnasimk/SS_backend/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterRestockRoutes adds back-in-stock alerts under the products group and starts the batched sender
+func RegisterRestockRoutes(rg *gin.RouterGroup) {
+	// Repositories
+	restockRepo := sql.NewRestockRepository(config.DB)
+	productRepo := sql.NewProductsRepository(*config.DB)
+
+	// Service
+	restockService := services.NewRestockService(restockRepo, productRepo, services.NewEmailService())
+	restockService.StartNotifier(time.Duration(config.AppConfig.RestockBatchSecs)*time.Second, config.AppConfig.RestockBatchSize)
+
+	// Controller
+	restockController := controllers.NewRestockController(restockService)
+
+	// Customers
+	customer := rg.Group("/:id/restock-alert")
+	customer.Use(middlewares.AuthorizeMiddleware(), middlewares.CustomerAuth())
+	{
+		customer.POST("", restockController.Subscribe)      // Subscribe while the product is sold out
+		customer.GET("", restockController.GetSubscription) // Am I subscribed?
+		customer.DELETE("", restockController.Unsubscribe)  // Cancel a pending alert
+	}
+}
@@ -0,0 +1,44 @@
+package routes
+
+import (
+	"github.com/akhilnasimk/SS_backend/internal/config"
+	"github.com/akhilnasimk/SS_backend/internal/controllers"
+	"github.com/akhilnasimk/SS_backend/internal/middlewares"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/sql"
+	"github.com/akhilnasimk/SS_backend/internal/services"
+	"github.com/akhilnasimk/SS_backend/utils/media"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterReviewRoutes adds product reviews under the products group (/products/:id/reviews)
+func RegisterReviewRoutes(rg *gin.RouterGroup, store media.MediaStore) {
+	// Repositories
+	reviewRepo := sql.NewReviewRepository(config.DB)
+	orderRepo := sql.NewOrderRepository(*config.DB)
+	productRepo := sql.NewProductsRepository(*config.DB)
+
+	// Service
+	reviewService := services.NewReviewService(reviewRepo, orderRepo, productRepo, store)
+
+	// Controller
+	reviewController := controllers.NewReviewController(reviewService)
+
+	// Public
+	rg.GET("/:id/reviews", reviewController.GetProductReviews) // Visible reviews of a product
+
+	// Customers (verified buyers only, checked in the service)
+	customer := rg.Group("/:id/reviews")
+	customer.Use(middlewares.AuthorizeMiddleware(), middlewares.CustomerAuth())
+	{
+		customer.POST("", reviewController.CreateReview) // Post a review (multipart, optional "photos")
+		customer.PUT("", reviewController.UpdateReview)  // Edit own review
+	}
+
+	// Moderation
+	admin := rg.Group("/admin")
+	admin.Use(middlewares.AuthorizeMiddleware(), middlewares.AdminAuth())
+	{
+		admin.GET("/:id/reviews", reviewController.GetProductReviewsAdmin)                  // All reviews incl. hidden
+		admin.PATCH("/reviews/:review_id/visibility", reviewController.SetReviewVisibility) // Hide / un-hide a review
+	}
+}
@@ -1,8 +1,13 @@
 package routes
 
 import (
+	"log"
+	"net/url"
+
 	_ "github.com/akhilnasimk/SS_backend/docs"
+	"github.com/akhilnasimk/SS_backend/internal/config"
 	"github.com/akhilnasimk/SS_backend/internal/middlewares"
+	"github.com/akhilnasimk/SS_backend/utils/media"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -21,6 +26,19 @@ func SetupRoutes(r *gin.Engine) {
 		})
 	})
 
+	// product image storage (cloudinary / local / memory, see MEDIA_BACKEND)
+	store, err := media.New(config.AppConfig)
+	if err != nil {
+		log.Fatal("Failed to initialize media storage: ", err)
+	}
+	if config.AppConfig.MediaBackend == media.BackendLocal {
+		base, err := url.Parse(config.AppConfig.MediaBaseURL)
+		if err != nil {
+			log.Fatal("Invalid MEDIA_BASE_URL: ", err)
+		}
+		r.Static(base.Path, config.AppConfig.MediaLocalDir)
+	}
+
 	api := r.Group("/api/v1")
 	// Auth routes: login, register, refresh
 	auth := api.Group("/auth")
@@ -28,7 +46,20 @@ func SetupRoutes(r *gin.Engine) {
 
 	// Produts route all related to products
 	product := api.Group("/products")
-	RegisterProductRoutes(product)
+	RegisterProductRoutes(product, store)
+	RegisterReviewRoutes(product, store)
+	RegisterQuestionRoutes(product)
+	RegisterRecommendationRoutes(product)
+	RegisterStockRoutes(product)
+	RegisterRestockRoutes(product)
+
+	// category tree and admin category management
+	categories := api.Group("/categories")
+	RegisterCategoryRoutes(categories)
+
+	// raffles for limited releases: entries, draw, winner purchase
+	raffles := api.Group("/raffles")
+	RegisterRaffleRoutes(raffles)
 
 	//routes that is related to users
 	users := api.Group("/users")
@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"github.com/akhilnasimk/SS_backend/internal/config"
+	"github.com/akhilnasimk/SS_backend/internal/controllers"
+	"github.com/akhilnasimk/SS_backend/internal/middlewares"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/sql"
+	"github.com/akhilnasimk/SS_backend/internal/services"
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterStockRoutes adds the admin stock ledger under the products group
+func RegisterStockRoutes(rg *gin.RouterGroup) {
+	// Repositories
+	stockRepo := sql.NewStockMovementRepository(config.DB)
+	productRepo := sql.NewProductsRepository(*config.DB)
+	alertRepo := sql.NewStockAlertRepository(config.DB)
+	userRepo := sql.NewUserReposetory(*config.DB)
+
+	// Services
+	stockService := services.NewStockService(stockRepo, productRepo)
+	alertService := services.NewStockAlertService(alertRepo, userRepo, services.NewEmailService())
+
+	// Controller
+	stockController := controllers.NewStockController(stockService, alertService)
+
+	// Admin
+	admin := rg.Group("/admin")
+	admin.Use(middlewares.AuthorizeMiddleware(), middlewares.AdminAuth())
+	{
+		admin.GET("/:id/stock-movements", stockController.GetStockHistory) // Ledger of one product, newest first
+		admin.POST("/:id/stock-movements", stockController.AdjustStock)    // Manual correction or customer return
+		admin.GET("/stock-reconciliation", stockController.ReconcileStock) // stock_count vs ledger, ?all=true for every product
+		admin.GET("/low-stock", stockController.GetLowStock)               // At or below threshold, or a size sold out
+	}
+}
@@ -12,10 +12,13 @@ import (
 func RegisterUserRoutes(rg *gin.RouterGroup) {
 	// Repository
 	userRepo := sql.NewUserReposetory(*config.DB)
+	recentlyViewedRepo := sql.NewRecentlyViewedRepository(config.DB)
 	// Service
 	userService := services.NewUserService(userRepo)
+	recentlyViewedService := services.NewRecentlyViewedService(recentlyViewedRepo)
 	// Controller
 	userController := controllers.NewUserController(userService)
+	recentlyViewedController := controllers.NewRecentlyViewedController(recentlyViewedService)
 
 	// ---------------------
 	// JWT Protected Routes
@@ -29,8 +32,9 @@ func RegisterUserRoutes(rg *gin.RouterGroup) {
 		customer := protected.Group("/")
 		customer.Use(middlewares.CustomerAuth()) // Ensure role == "customer"
 		{
-			customer.GET("/me", userController.GetProfile)      // Get own profile
-			customer.PATCH("/me", userController.UpdateProfile) // Update own profile
+			customer.GET("/me", userController.GetProfile)                        // Get own profile
+			customer.PATCH("/me", userController.UpdateProfile)                   // Update own profile
+			customer.GET("/me/recently-viewed", recentlyViewedController.GetMine) // Last viewed products, newest first
 		}
 
 		// ---------------------
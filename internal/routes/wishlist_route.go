@@ -15,7 +15,7 @@ func RegisterWishlistRoute(rg gin.RouterGroup) {
 	productRepo := sql.NewProductsRepository(*config.DB)
 
 	// Service
-	wishService := services.NewWishlistService(wishrepo, productRepo)
+	wishService := services.NewWishlistService(wishrepo, productRepo, services.NewEmailService())
 
 	// Controller
 	wishController := controllers.NewWishlistController(wishService)
@@ -15,7 +15,7 @@ import (
 )
 
 type AuthService interface {
-	Register(User dto.RegisterRequest) error
+	Register(User dto.RegisterRequest) (*models.User, error)
 	Login(user dto.LoginReq) (*models.User, error)
 	GenerateAndStoreRefreshToken(userID uuid.UUID) (string, error)
 	RefreshTokens(token string) (string, string, error)
@@ -37,7 +37,7 @@ func NewAuthService(repo interfaces.UserRepository, tokenRepo interfaces.TokenRe
 	}
 }
 
-func (S *authService) Register(User dto.RegisterRequest) error {
+func (S *authService) Register(User dto.RegisterRequest) (*models.User, error) {
 	user := models.User{
 		UserName: User.UserName,
 		Email:    User.Email,
@@ -48,25 +48,25 @@ func (S *authService) Register(User dto.RegisterRequest) error {
 	// checking if user exist
 	existingUser, err := S.userRepo.FindByEmail(user.Email)
 	if err != nil {
-		return fmt.Errorf("failed to check existing user: %w", err)
+		return nil, fmt.Errorf("failed to check existing user: %w", err)
 	}
 	if existingUser != nil {
-		return fmt.Errorf("user with email %s already exists", user.Email)
+		return nil, fmt.Errorf("user with email %s already exists", user.Email)
 	}
 
 	// Hashing pass
 	hashedPass, err := helpers.HashPassword(user.Password)
 	if err != nil {
-		return fmt.Errorf("failed to hash password: %w", err)
+		return nil, fmt.Errorf("failed to hash password: %w", err)
 	}
 	user.Password = string(hashedPass) // setting password
 
 	// Registering user with the injected user repo methode
-	if err := S.userRepo.CreateUser(user); err != nil {
-		return fmt.Errorf("failed to register user: %w", err)
+	if err := S.userRepo.CreateUser(&user); err != nil {
+		return nil, fmt.Errorf("failed to register user: %w", err)
 	}
 	log.Println("regist sucess")
-	return nil
+	return &user, nil
 }
 
 func (S *authService) Login(req dto.LoginReq) (*models.User, error) {
@@ -1,19 +1,28 @@
 package services
 
 import (
+	"errors"
 	"fmt"
+	"log"
+	"time"
 
+	"github.com/akhilnasimk/SS_backend/internal/config"
+	constent "github.com/akhilnasimk/SS_backend/internal/const"
 	"github.com/akhilnasimk/SS_backend/internal/dto"
 	"github.com/akhilnasimk/SS_backend/internal/helpers"
+	"github.com/akhilnasimk/SS_backend/internal/models"
 	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
 	"github.com/google/uuid"
 )
 
+// The cart is the logged-in user's, or the guest's (guest_id cookie) when not logged in
 type CartService interface {
-	GetUserCartItems(userID uuid.UUID) (dto.CartResponse, error)
-	AddItemToCart(userID uuid.UUID, productID uuid.UUID) (*dto.CartItemResponse, error)
-	IncOrDecCartItem(idstring string, oper string) error
-	DeleteCartItem(idstring string) error
+	GetUserCartItems(userIDString, guestIDString string) (dto.CartResponse, error)
+	AddItemToCart(userIDString, guestIDString string, productID uuid.UUID, variantID *uuid.UUID) (*dto.CartItemResponse, error)
+	IncOrDecCartItem(userIDString, guestIDString string, idstring string, oper string) error
+	DeleteCartItem(userIDString, guestIDString string, idstring string) error
+	MergeGuestCart(guestID, userID uuid.UUID) error
+	StartGuestCartExpiry(interval time.Duration)
 }
 
 type cartService struct {
@@ -30,17 +39,32 @@ func NewCartService(cartRepo interfaces.CartRepository, ProductRep interfaces.Pr
 }
 
 // Fetch cart and map to DTO
-func (s *cartService) GetUserCartItems(userID uuid.UUID) (dto.CartResponse, error) {
-	cart, err := s.cartRepo.FindAllcartItemsOfUser(userID)
+func (s *cartService) GetUserCartItems(userIDString, guestIDString string) (dto.CartResponse, error) {
+	owner, err := cartOwner(userIDString, guestIDString)
+	if err != nil {
+		return dto.CartResponse{}, err
+	}
+
+	var cart models.Cart
+	if owner.UserID != nil {
+		cart, err = s.cartRepo.FindAllcartItemsOfUser(*owner.UserID)
+	} else {
+		cart, err = s.cartRepo.FindGuestCart(*owner.GuestID)
+	}
 	if err != nil {
 		return dto.CartResponse{}, err
 	}
 
 	return dto.MapCartToCartResponse(cart), nil
 }
-func (s *cartService) AddItemToCart(userID uuid.UUID, productID uuid.UUID) (*dto.CartItemResponse, error) {
+func (s *cartService) AddItemToCart(userIDString, guestIDString string, productID uuid.UUID, variantID *uuid.UUID) (*dto.CartItemResponse, error) {
+	owner, err := cartOwner(userIDString, guestIDString)
+	if err != nil {
+		return nil, err
+	}
+
 	// Add to cart (no need for separate product validation as repo does it)
-	cartItem, err := s.cartRepo.AddItemToCart(userID, productID)
+	cartItem, err := s.cartRepo.AddItemToCart(owner, productID, variantID, purchaseLimitSince())
 	if err != nil {
 		return nil, fmt.Errorf("failed adding item to cart: %w", err)
 	}
@@ -51,7 +75,13 @@ func (s *cartService) AddItemToCart(userID uuid.UUID, productID uuid.UUID) (*dto
 	// Build photo URL (handle empty images)
 	photoURL := ""
 	if len(product.Images) > 0 {
-		photoURL = product.Images[0].URL
+		photoURL = product.Images[0].SizedURL(constent.ImageSizeThumbnail)
+	}
+
+	// Variant price overrides the product price
+	price := product.Price
+	if cartItem.Variant != nil {
+		price = cartItem.Variant.EffectivePrice(product.Price)
 	}
 
 	// Map to response
@@ -59,22 +89,28 @@ func (s *cartService) AddItemToCart(userID uuid.UUID, productID uuid.UUID) (*dto
 		CartItemID:  cartItem.ID,
 		ProductID:   product.ID,
 		ProductName: product.Name,
-		Price:       float64(product.Price),
+		Price:       float64(price),
 		Photo:       photoURL,
-		Total:       float64(product.Price) * float64(cartItem.Quantity),
+		Total:       float64(price) * float64(cartItem.Quantity),
 		Quantity:    cartItem.Quantity,
 		Catogory:    product.CategoryID,
 	}
+	dto.ApplyCartVariant(resp, cartItem.Variant)
 
 	return resp, nil
 }
 
 // patch the quantity of the cart items
-func (s *cartService) IncOrDecCartItem(idstring string, oper string) error {
+func (s *cartService) IncOrDecCartItem(userIDString, guestIDString string, idstring string, oper string) error {
 	if idstring == "" {
 		return fmt.Errorf("the id is not given")
 	}
 
+	owner, err := cartOwner(userIDString, guestIDString)
+	if err != nil {
+		return err
+	}
+
 	// Validate UUID
 	id := helpers.StringToUUID(idstring)
 
@@ -84,19 +120,82 @@ func (s *cartService) IncOrDecCartItem(idstring string, oper string) error {
 	}
 
 	// Call repository
-	if err := s.cartRepo.PatchQuantity(id, oper); err != nil {
+	if err := s.cartRepo.PatchQuantity(owner, id, oper, purchaseLimitSince()); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (s *cartService) DeleteCartItem(idstring string) error {
+func (s *cartService) DeleteCartItem(userIDString, guestIDString string, idstring string) error {
+	owner, err := cartOwner(userIDString, guestIDString)
+	if err != nil {
+		return err
+	}
+
 	id := helpers.StringToUUID(idstring)
 
-	err := s.cartRepo.HardDeleteCartItem(id)
+	err = s.cartRepo.HardDeleteCartItem(owner, id)
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+// MergeGuestCart moves the guest's cart into the user's on login or register
+func (s *cartService) MergeGuestCart(guestID, userID uuid.UUID) error {
+	return s.cartRepo.MergeGuestCart(guestID, userID)
+}
+
+// StartGuestCartExpiry deletes guest carts left untouched for GUEST_CART_TTL_DAYS on every tick, in the background
+func (s *cartService) StartGuestCartExpiry(interval time.Duration) {
+	ttl := config.AppConfig.GuestCartTTLDays
+	if interval <= 0 || ttl <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			removed, err := s.cartRepo.DeleteStaleGuestCarts(time.Now().AddDate(0, 0, -ttl))
+			if err != nil {
+				log.Printf("guest cart expiry failed: %v", err)
+				continue
+			}
+			if removed > 0 {
+				log.Printf("deleted %d stale guest carts", removed)
+			}
+		}
+	}()
+}
+
+// cartOwner is the cart owner for the repository: the user when logged in, the guest otherwise
+func cartOwner(userIDString, guestIDString string) (models.Cart, error) {
+	switch {
+	case userIDString != "":
+		userID, err := uuid.Parse(userIDString)
+		if err != nil {
+			return models.Cart{}, errors.New("invalid user ID")
+		}
+		return models.Cart{UserID: &userID}, nil
+	case guestIDString != "":
+		guestID, err := uuid.Parse(guestIDString)
+		if err != nil {
+			return models.Cart{}, errors.New("invalid guest ID")
+		}
+		return models.Cart{GuestID: &guestID}, nil
+	default:
+		return models.Cart{}, errors.New("user not authenticated")
+	}
+}
+
+// purchaseLimitSince is the start of the max_per_customer window (PURCHASE_LIMIT_DAYS, 0 = all time)
+func purchaseLimitSince() time.Time {
+	days := config.AppConfig.PurchaseLimitDays
+	if days <= 0 {
+		return time.Time{}
+	}
+	return time.Now().AddDate(0, 0, -days)
+}
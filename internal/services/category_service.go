@@ -0,0 +1,245 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	constent "github.com/akhilnasimk/SS_backend/internal/const"
+	"github.com/akhilnasimk/SS_backend/internal/dto"
+	"github.com/akhilnasimk/SS_backend/internal/helpers"
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
+	"github.com/google/uuid"
+)
+
+type CategoryService interface {
+	GetCategoryTree() ([]dto.CategoryResponse, error)
+	GetCategory(id string) (dto.CategoryResponse, error)
+	GetCategoryBySlug(slug string) (dto.CategoryResponse, string, error)
+	CreateCategory(req dto.CategoryRequest) (dto.CategoryResponse, error)
+	UpdateCategory(id string, req dto.CategoryRequest) (dto.CategoryResponse, error)
+	DeleteCategory(id string, reassignTo string) error
+}
+
+type categoryService struct {
+	categoryRepo interfaces.CategoryRepository
+	redirectRepo interfaces.SlugRedirectRepository
+}
+
+func NewCategoryService(categoryRepo interfaces.CategoryRepository, redirectRepo interfaces.SlugRedirectRepository) CategoryService {
+	return &categoryService{
+		categoryRepo: categoryRepo,
+		redirectRepo: redirectRepo,
+	}
+}
+
+// GetCategoryTree returns the top level categories with their children nested
+func (s *categoryService) GetCategoryTree() ([]dto.CategoryResponse, error) {
+	categories, err := s.categoryRepo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// group by parent, FindAll already keeps display order inside each group
+	byParent := make(map[uuid.UUID][]models.Category)
+	for _, c := range categories {
+		parent := uuid.Nil
+		if c.ParentID != nil {
+			parent = *c.ParentID
+		}
+		byParent[parent] = append(byParent[parent], c)
+	}
+
+	var build func(parent uuid.UUID) []dto.CategoryResponse
+	build = func(parent uuid.UUID) []dto.CategoryResponse {
+		nodes := make([]dto.CategoryResponse, 0, len(byParent[parent]))
+		for _, c := range byParent[parent] {
+			node := dto.ToCategoryResponse(c)
+			node.Children = build(c.ID)
+			nodes = append(nodes, node)
+		}
+		return nodes
+	}
+
+	return build(uuid.Nil), nil
+}
+
+func (s *categoryService) GetCategory(id string) (dto.CategoryResponse, error) {
+	categoryID, err := uuid.Parse(id)
+	if err != nil {
+		return dto.CategoryResponse{}, errors.New("invalid category ID")
+	}
+
+	category, err := s.categoryRepo.FindByID(categoryID)
+	if err != nil {
+		return dto.CategoryResponse{}, err
+	}
+
+	return dto.ToCategoryResponse(*category), nil
+}
+
+// GetCategoryBySlug resolves a current slug, or an old one: then the category's current slug is
+// returned as the redirect target and the response is empty
+func (s *categoryService) GetCategoryBySlug(slug string) (dto.CategoryResponse, string, error) {
+	category, err := s.categoryRepo.FindBySlug(slug)
+	if err == nil {
+		return dto.ToCategoryResponse(*category), "", nil
+	}
+
+	redirect, rerr := s.redirectRepo.FindRedirect(constent.SlugEntityCategory, slug)
+	if rerr != nil {
+		return dto.CategoryResponse{}, "", rerr
+	}
+	if redirect == nil {
+		return dto.CategoryResponse{}, "", err
+	}
+
+	category, err = s.categoryRepo.FindByID(redirect.EntityID)
+	if err != nil {
+		return dto.CategoryResponse{}, "", err
+	}
+	return dto.CategoryResponse{}, category.Slug, nil
+}
+
+func (s *categoryService) CreateCategory(req dto.CategoryRequest) (dto.CategoryResponse, error) {
+	category := models.Category{
+		ID:           uuid.New(),
+		Name:         strings.TrimSpace(req.Name),
+		DisplayOrder: req.DisplayOrder,
+	}
+
+	if err := s.applyCategoryRequest(&category, req); err != nil {
+		return dto.CategoryResponse{}, err
+	}
+
+	if err := s.categoryRepo.Create(&category); err != nil {
+		return dto.CategoryResponse{}, fmt.Errorf("failed to create category: %w", err)
+	}
+
+	return dto.ToCategoryResponse(category), nil
+}
+
+func (s *categoryService) UpdateCategory(id string, req dto.CategoryRequest) (dto.CategoryResponse, error) {
+	categoryID, err := uuid.Parse(id)
+	if err != nil {
+		return dto.CategoryResponse{}, errors.New("invalid category ID")
+	}
+
+	category, err := s.categoryRepo.FindByID(categoryID)
+	if err != nil {
+		return dto.CategoryResponse{}, err
+	}
+
+	nameChanged := !strings.EqualFold(category.Name, strings.TrimSpace(req.Name))
+	category.Name = strings.TrimSpace(req.Name)
+	category.DisplayOrder = req.DisplayOrder
+
+	// keep the current slug on a plain edit so existing links don't break
+	if req.Slug == "" && !nameChanged {
+		req.Slug = category.Slug
+	}
+
+	if err := s.applyCategoryRequest(category, req); err != nil {
+		return dto.CategoryResponse{}, err
+	}
+
+	if err := s.categoryRepo.Update(category); err != nil {
+		return dto.CategoryResponse{}, fmt.Errorf("failed to update category: %w", err)
+	}
+
+	return dto.ToCategoryResponse(*category), nil
+}
+
+// applyCategoryRequest validates the parent and name, and resolves a unique slug
+func (s *categoryService) applyCategoryRequest(category *models.Category, req dto.CategoryRequest) error {
+	if category.Name == "" {
+		return errors.New("category name is required")
+	}
+
+	category.ParentID = nil
+	if req.ParentID != "" {
+		parentID, err := uuid.Parse(req.ParentID)
+		if err != nil {
+			return errors.New("invalid parent category ID")
+		}
+
+		if _, err := s.categoryRepo.FindByID(parentID); err != nil {
+			return fmt.Errorf("parent %w", err)
+		}
+
+		// a category can't move under itself or one of its own children
+		cycle, err := s.categoryRepo.IsDescendant(parentID, category.ID)
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return errors.New("a category cannot be nested under itself or its sub-categories")
+		}
+
+		category.ParentID = &parentID
+	}
+
+	exists, err := s.categoryRepo.NameExists(category.Name, category.ParentID, category.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("category %q already exists at this level", category.Name)
+	}
+
+	slug, err := s.uniqueSlug(req.Slug, category.Name, category.ID)
+	if err != nil {
+		return err
+	}
+	category.Slug = slug
+
+	return nil
+}
+
+// uniqueSlug slugifies the requested slug (or the name) and appends -2, -3... until it is free
+func (s *categoryService) uniqueSlug(requested, name string, id uuid.UUID) (string, error) {
+	base := helpers.Slugify(requested)
+	if base == "" {
+		base = helpers.Slugify(name)
+	}
+	if base == "" {
+		return "", errors.New("category name must contain letters or digits")
+	}
+
+	slug := base
+	for n := 2; ; n++ {
+		exists, err := s.categoryRepo.SlugExists(slug, id)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return slug, nil
+		}
+		slug = fmt.Sprintf("%s-%d", base, n)
+	}
+}
+
+func (s *categoryService) DeleteCategory(id string, reassignTo string) error {
+	categoryID, err := uuid.Parse(id)
+	if err != nil {
+		return errors.New("invalid category ID")
+	}
+
+	var target *uuid.UUID
+	if reassignTo != "" {
+		targetID, err := uuid.Parse(reassignTo)
+		if err != nil {
+			return errors.New("invalid reassign_to category ID")
+		}
+		if targetID == categoryID {
+			return errors.New("reassign_to must be a different category")
+		}
+		if _, err := s.categoryRepo.FindByID(targetID); err != nil {
+			return fmt.Errorf("reassign_to %w", err)
+		}
+		target = &targetID
+	}
+
+	return s.categoryRepo.Delete(categoryID, target)
+}
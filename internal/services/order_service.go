@@ -2,9 +2,12 @@ package services
 
 import (
 	"fmt"
+	"log"
 	"time"
 
+	"github.com/akhilnasimk/SS_backend/internal/config"
 	constent "github.com/akhilnasimk/SS_backend/internal/const"
+	"github.com/akhilnasimk/SS_backend/internal/dto"
 	"github.com/akhilnasimk/SS_backend/internal/helpers"
 	"github.com/akhilnasimk/SS_backend/internal/models"
 	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
@@ -13,22 +16,29 @@ import (
 
 type OrderService interface {
 	GetAllOrders(userID string) ([]models.Order, error)
+	GetOrdersByCursor(userID string, limit int, cursor string) ([]models.Order, string, error)
 	CreateOrderFromCart(userIDString, shippingAddress, paymentMethod string) (*models.Order, error)
-	CreateSingleOrder(userIDString string, productIDString string, quantity int, shippingAddress string, paymentMethod string) (*models.Order, error)
-	CancelSingleOrderItem(orderItemIdString string) error
+	CreateSingleOrder(userIDString string, productIDString string, variantIDString string, quantity int, shippingAddress string, paymentMethod string) (*models.Order, error)
+	CancelSingleOrderItem(orderItemIdString string, userID uuid.UUID) error
 	CancelEntireOrder(orderIDStr string, userID uuid.UUID) error
 	UpdateOrderStatus(orderID string, newStatus string) error
+	StartCheckout(userIDString string) (dto.CheckoutHoldResponse, error)
+	StartHoldCleanup(interval time.Duration)
 }
 
 type orderService struct {
 	OrderRepo interfaces.OrderRepository
 	CartRepo  interfaces.CartRepository
+	HoldRepo  interfaces.StockHoldRepository
+	Alerts    StockAlertService
 }
 
-func NewOrderService(orderRepo interfaces.OrderRepository, cartRepo interfaces.CartRepository) OrderService {
+func NewOrderService(orderRepo interfaces.OrderRepository, cartRepo interfaces.CartRepository, holdRepo interfaces.StockHoldRepository, alerts StockAlertService) OrderService {
 	return &orderService{
 		OrderRepo: orderRepo,
 		CartRepo:  cartRepo,
+		HoldRepo:  holdRepo,
+		Alerts:    alerts,
 	}
 }
 
@@ -44,6 +54,32 @@ func (s *orderService) GetAllOrders(userID string) ([]models.Order, error) {
 	return s.OrderRepo.FindAllOrders(U_id)
 }
 
+// keyset-paginated order history (newest first)
+func (s *orderService) GetOrdersByCursor(userID string, limit int, cursor string) ([]models.Order, string, error) {
+	after, err := parseKeyset(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	limit = clampLimit(limit)
+	U_id := helpers.StringToUUID(userID)
+
+	orders, err := s.OrderRepo.FindOrdersByCursor(U_id, limit+1, after)
+	if err != nil {
+		return nil, "", err
+	}
+
+	orders, hasMore := helpers.TrimPage(orders, limit)
+
+	next := ""
+	if len(orders) > 0 {
+		last := orders[len(orders)-1]
+		next = nextCursor(hasMore, last.CreatedAt, last.ID)
+	}
+
+	return orders, next, nil
+}
+
 // -----------------------------------------------------------
 // 2. Place Order From Entire Cart
 // -----------------------------------------------------------
@@ -62,7 +98,11 @@ func (s *orderService) CreateOrderFromCart(userIDString, shippingAddress, paymen
 	// 2. Calculate total amount
 	var total float64
 	for _, item := range cartItems.CartItems {
-		total += float64(item.Quantity) * float64(item.Product.Price)
+		price := item.Product.Price
+		if item.Variant != nil {
+			price = item.Variant.EffectivePrice(price)
+		}
+		total += float64(item.Quantity) * float64(price)
 	}
 
 	// 3. Create order model
@@ -75,17 +115,87 @@ func (s *orderService) CreateOrderFromCart(userIDString, shippingAddress, paymen
 	}
 
 	// 4. Create order + items together (handles stock, snapshots, cart deletion)
-	if err := s.OrderRepo.CreateOrderWithItems(order, cartItems.CartItems); err != nil {
+	if err := s.OrderRepo.CreateOrderWithItems(order, cartItems.CartItems, purchaseLimitSince()); err != nil {
 		return nil, fmt.Errorf("failed to create order: %w", err)
 	}
 
+	// 5. Tell admins about anything this order pushed below its threshold
+	productIDs := make([]uuid.UUID, 0, len(cartItems.CartItems))
+	for _, item := range cartItems.CartItems {
+		productIDs = append(productIDs, item.ProductID)
+	}
+	go s.Alerts.CheckProducts(productIDs)
+
 	return order, nil
 }
 
+// StartCheckout holds the cart's quantities for CHECKOUT_HOLD_MINUTES so nobody else can
+// buy them meanwhile. Calling it again refreshes the holds from the current cart but keeps
+// the original expiry until it has passed.
+func (s *orderService) StartCheckout(userIDString string) (dto.CheckoutHoldResponse, error) {
+	userID := helpers.StringToUUID(userIDString)
+
+	cart, err := s.CartRepo.FindAllcartItemsOfUser(userID)
+	if err != nil || len(cart.CartItems) == 0 {
+		return dto.CheckoutHoldResponse{}, fmt.Errorf("cart is empty")
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(time.Duration(config.AppConfig.CheckoutHoldMinutes) * time.Minute)
+
+	holds, err := s.HoldRepo.HoldCart(userID, cart.CartItems, expiresAt, now)
+	if err != nil {
+		return dto.CheckoutHoldResponse{}, err
+	}
+
+	// holds carry over the expiry of a checkout that is still running
+	if len(holds) > 0 {
+		expiresAt = holds[0].ExpiresAt
+	}
+
+	resp := dto.CheckoutHoldResponse{
+		ExpiresAt: expiresAt,
+		Items:     make([]dto.CheckoutHoldItem, 0, len(holds)),
+	}
+	for _, h := range holds {
+		resp.Items = append(resp.Items, dto.CheckoutHoldItem{
+			ProductID: h.ProductID,
+			VariantID: h.VariantID,
+			Quantity:  h.Quantity,
+		})
+	}
+
+	return resp, nil
+}
+
+// StartHoldCleanup deletes expired checkout holds on every tick, in the background.
+// Expired holds already stop counting, this only keeps the table small.
+func (s *orderService) StartHoldCleanup(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			released, err := s.HoldRepo.DeleteExpired(time.Now())
+			if err != nil {
+				log.Printf("checkout hold cleanup failed: %v", err)
+				continue
+			}
+			if released > 0 {
+				log.Printf("released %d expired checkout holds", released)
+			}
+		}
+	}()
+}
+
 // -----------------------------------------------------------
 // 3. Place Order For A Single Product
 // -----------------------------------------------------------
-func (s *orderService) CreateSingleOrder(userIDString string, productIDString string, quantity int, shippingAddress string, paymentMethod string) (*models.Order, error) {
+func (s *orderService) CreateSingleOrder(userIDString string, productIDString string, variantIDString string, quantity int, shippingAddress string, paymentMethod string) (*models.Order, error) {
 	// Validation
 	if quantity <= 0 {
 		return nil, fmt.Errorf("quantity must be greater than 0")
@@ -94,6 +204,16 @@ func (s *orderService) CreateSingleOrder(userIDString string, productIDString st
 	userID := helpers.StringToUUID(userIDString)
 	productID := helpers.StringToUUID(productIDString)
 
+	// Optional size selection
+	var variantID *uuid.UUID
+	if variantIDString != "" {
+		id, err := uuid.Parse(variantIDString)
+		if err != nil {
+			return nil, fmt.Errorf("invalid variant id")
+		}
+		variantID = &id
+	}
+
 	// Create order (TotalAmount will be set by repository after fetching product price)
 	order := &models.Order{
 		UserID:          userID,
@@ -104,15 +224,17 @@ func (s *orderService) CreateSingleOrder(userIDString string, productIDString st
 	}
 
 	// Create order with single item (handles stock, snapshot, total calculation)
-	if err := s.OrderRepo.CreateSingleOrder(order, productID, quantity); err != nil {
+	if err := s.OrderRepo.CreateSingleOrder(order, productID, variantID, quantity, purchaseLimitSince()); err != nil {
 		return nil, fmt.Errorf("failed to create order: %w", err)
 	}
 
+	go s.Alerts.CheckProducts([]uuid.UUID{productID})
+
 	return order, nil
 }
 
 // cancel th singel orderitem
-func (s *orderService) CancelSingleOrderItem(orderItemIdString string) error {
+func (s *orderService) CancelSingleOrderItem(orderItemIdString string, userID uuid.UUID) error {
 	// Parse ID
 	id := helpers.StringToUUID(orderItemIdString)
 	if id == uuid.Nil {
@@ -131,7 +253,7 @@ func (s *orderService) CancelSingleOrderItem(orderItemIdString string) error {
 	}
 
 	// Execute cancellation
-	return s.OrderRepo.CancelSingleOrderItem(orderItem.ID)
+	return s.OrderRepo.CancelSingleOrderItem(orderItem.ID, userID)
 }
 
 // CANCEL ENTIRE ORDER
@@ -159,7 +281,7 @@ func (s *orderService) CancelEntireOrder(orderIDStr string, userID uuid.UUID) er
 	}
 
 	// Execute cancellation
-	return s.OrderRepo.CancelWholeOrder(order.ID)
+	return s.OrderRepo.CancelWholeOrder(order.ID, userID)
 }
 
 // VALIDATION - Only 2 Checks on Parent Order bussinsess logic
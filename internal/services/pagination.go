@@ -0,0 +1,47 @@
+package services
+
+import (
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/helpers"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultPageLimit = 10
+	maxPageLimit     = 100
+)
+
+// clampLimit keeps the page size between 1 and maxPageLimit
+func clampLimit(limit int) int {
+	if limit <= 0 {
+		return defaultPageLimit
+	}
+	if limit > maxPageLimit {
+		return maxPageLimit
+	}
+	return limit
+}
+
+// parseKeyset turns an opaque cursor into a repository keyset (nil = first page)
+func parseKeyset(cursor string) (*interfaces.Keyset, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	createdAt, id, err := helpers.DecodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return &interfaces.Keyset{CreatedAt: createdAt, ID: id}, nil
+}
+
+// nextCursor returns the cursor of the last row, or "" when there is no next page
+func nextCursor(hasMore bool, createdAt time.Time, id uuid.UUID) string {
+	if !hasMore {
+		return ""
+	}
+	return helpers.EncodeCursor(createdAt, id)
+}
@@ -0,0 +1,417 @@
+package services
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/akhilnasimk/SS_backend/internal/config"
+	"github.com/akhilnasimk/SS_backend/internal/dto"
+	"github.com/akhilnasimk/SS_backend/internal/helpers"
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CSV layout shared by import and export. image_urls are separated by "|".
+// category is a category name, or a full path ("Men > Running > Trail") when the name is not unique.
+// is_active is optional: empty keeps an existing product's state and makes new products active.
+var productCSVHeader = []string{"id", "name", "description", "price", "stock_count", "category", "image_urls", "is_active"}
+
+const (
+	imageURLSeparator = "|"
+	categoryPathSep   = " > "
+	maxImportRows     = 5000
+)
+
+// importRow is a validated CSV line ready to be written
+type importRow struct {
+	line      int
+	productID uuid.UUID // set when the row updates an existing product
+	req       dto.UpdateProductRequest
+	imageURLs []string
+	isActive  *bool // nil when the column is empty
+}
+
+// priceDrop is an imported price cut, announced to wishlisters once the import is saved
+type priceDrop struct {
+	productID uuid.UUID
+	name      string
+	oldPrice  int64
+	newPrice  int64
+}
+
+// ImportProductsCSV validates every row first; rows are only written when
+// dryRun is false and the whole file is valid, inside a single transaction
+func (s *productsService) ImportProductsCSV(r io.Reader, dryRun bool, adminID uuid.UUID) (dto.ProductImportReport, error) {
+	report := dto.ProductImportReport{DryRun: dryRun, Errors: []dto.ImportRowError{}}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return report, fmt.Errorf("invalid csv: could not read header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, h := range header {
+		columns[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	for _, required := range []string{"name", "price", "stock_count", "category"} {
+		if _, ok := columns[required]; !ok {
+			return report, fmt.Errorf("invalid csv: missing column %q", required)
+		}
+	}
+
+	categories, err := s.productRepo.FindAllCategory()
+	if err != nil {
+		return report, err
+	}
+	resolveCategory := categoryResolver(categories)
+
+	var rows []importRow
+	seenNames := make(map[string]int)
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			report.Errors = append(report.Errors, dto.ImportRowError{Row: line, Message: err.Error()})
+			continue
+		}
+
+		report.TotalRows++
+		if report.TotalRows > maxImportRows {
+			return report, fmt.Errorf("invalid csv: more than %d rows", maxImportRows)
+		}
+
+		row, err := s.parseImportRow(record, columns, resolveCategory)
+		if err != nil {
+			report.Errors = append(report.Errors, dto.ImportRowError{Row: line, Message: err.Error()})
+			continue
+		}
+		row.line = line
+
+		key := strings.ToLower(row.req.Name)
+		if first, dup := seenNames[key]; dup {
+			report.Errors = append(report.Errors, dto.ImportRowError{Row: line, Message: fmt.Sprintf("duplicate product name, already on row %d", first)})
+			continue
+		}
+		seenNames[key] = line
+
+		if row.productID != uuid.Nil {
+			report.Updated++
+		} else {
+			report.Created++
+		}
+		rows = append(rows, row)
+	}
+
+	if dryRun || len(report.Errors) > 0 {
+		return report, nil
+	}
+
+	var drops []priceDrop
+	err = s.productRepo.Transaction(func(repo interfaces.ProductsRepository) error {
+		for _, row := range rows {
+			drop, err := applyImportRow(repo, row, adminID)
+			if err != nil {
+				return fmt.Errorf("row %d: %w", row.line, err)
+			}
+			if drop != nil {
+				drops = append(drops, *drop)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return report, fmt.Errorf("import failed, nothing was saved: %w", err)
+	}
+
+	// same wishlist emails as a price cut through UpdateProduct
+	for _, d := range drops {
+		go s.wishlist.NotifyPriceDrop(d.productID, d.name, d.oldPrice, d.newPrice)
+	}
+
+	return report, nil
+}
+
+// parseImportRow turns a CSV record into the same request the update endpoint validates
+func (s *productsService) parseImportRow(record []string, columns map[string]int, resolveCategory func(string) (uuid.UUID, error)) (importRow, error) {
+	field := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var row importRow
+
+	price, err := strconv.ParseInt(field("price"), 10, 64)
+	if err != nil {
+		return row, fmt.Errorf("invalid price %q", field("price"))
+	}
+
+	stock, err := strconv.Atoi(field("stock_count"))
+	if err != nil {
+		return row, fmt.Errorf("invalid stock_count %q", field("stock_count"))
+	}
+
+	row.req = dto.UpdateProductRequest{
+		Name:        field("name"),
+		Description: field("description"),
+		Price:       price,
+		StockCount:  stock,
+	}
+
+	if raw := field("is_active"); raw != "" {
+		active, err := strconv.ParseBool(raw)
+		if err != nil {
+			return row, fmt.Errorf("invalid is_active %q", raw)
+		}
+		row.isActive = &active
+	}
+
+	if field("category") != "" {
+		categoryID, err := resolveCategory(field("category"))
+		if err != nil {
+			return row, err
+		}
+		row.req.CategoryID = categoryID.String()
+	}
+
+	if err := helpers.ValidateUpdateProductRequest(row.req); err != nil {
+		return row, err
+	}
+
+	for _, raw := range strings.Split(field("image_urls"), imageURLSeparator) {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		u, err := url.ParseRequestURI(raw)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return row, fmt.Errorf("invalid image url %q", raw)
+		}
+		row.imageURLs = append(row.imageURLs, raw)
+	}
+
+	// Upsert: an explicit id must exist, otherwise match by name
+	var existing *models.Product
+	if idStr := field("id"); idStr != "" {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			return row, fmt.Errorf("invalid id %q", idStr)
+		}
+		existing, err = s.productRepo.FindById(id)
+		if err != nil {
+			return row, fmt.Errorf("product not found with id: %s", idStr)
+		}
+	} else {
+		existing, err = s.productRepo.FindByName(row.req.Name)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return row, err
+		}
+	}
+
+	if existing != nil {
+		row.productID = existing.ID
+	} else if len(row.imageURLs) == 0 {
+		// same rule as UploadProduct
+		return row, errors.New("at least one image url is required for a new product")
+	}
+
+	return row, nil
+}
+
+// applyImportRow writes one validated row through the regular create / update repository paths.
+// It returns the price drop when the row lowered an existing product's price.
+func applyImportRow(repo interfaces.ProductsRepository, row importRow, adminID uuid.UUID) (*priceDrop, error) {
+	categoryID := uuid.MustParse(row.req.CategoryID)
+
+	if row.productID == uuid.Nil {
+		product := models.Product{
+			Name:        row.req.Name,
+			Description: row.req.Description,
+			Price:       row.req.Price,
+			StockCount:  row.req.StockCount,
+			CategoryID:  categoryID,
+			IsActive:    row.isActive == nil || *row.isActive,
+
+			LowStockThreshold: config.AppConfig.LowStockThreshold,
+		}
+
+		slug, err := uniqueProductSlug(repo, "", product.Name, uuid.Nil)
+		if err != nil {
+			return nil, err
+		}
+		product.Slug = slug
+
+		images := make([]models.ProductImage, 0, len(row.imageURLs))
+		for i, u := range row.imageURLs {
+			images = append(images, models.ProductImage{URL: u, AltText: row.req.Name, Priority: i})
+		}
+
+		_, err = repo.CreateProductWithImages(product, images, adminID)
+		return nil, err
+	}
+
+	product, err := repo.FindById(row.productID)
+	if err != nil {
+		return nil, fmt.Errorf("product not found: %w", err)
+	}
+	oldPrice := product.Price
+
+	product.Name = row.req.Name
+	product.Description = row.req.Description
+	product.Price = row.req.Price
+	product.CategoryID = categoryID
+	if row.isActive != nil {
+		product.IsActive = *row.isActive
+	}
+
+	// With active variants the product stock is the sum of their stock
+	variants, err := repo.FindVariantsByProduct(product.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load variants: %w", err)
+	}
+	if !hasActiveVariant(variants) {
+		product.StockCount = row.req.StockCount
+	}
+
+	// image urls are appended, existing images are never dropped by an import
+	existing := make(map[string]struct{}, len(product.Images))
+	for _, img := range product.Images {
+		existing[img.URL] = struct{}{}
+	}
+	for _, u := range row.imageURLs {
+		if _, ok := existing[u]; ok {
+			continue
+		}
+		product.Images = append(product.Images, models.ProductImage{
+			URL:       u,
+			AltText:   product.Name,
+			ProductID: product.ID,
+			Priority:  len(product.Images),
+		})
+		existing[u] = struct{}{}
+	}
+
+	if err := repo.UpdateProduct(product, adminID); err != nil {
+		return nil, err
+	}
+
+	if product.Price < oldPrice {
+		return &priceDrop{productID: product.ID, name: product.Name, oldPrice: oldPrice, newPrice: product.Price}, nil
+	}
+	return nil, nil
+}
+
+// ExportProductsCSV writes the live catalog in the import layout
+func (s *productsService) ExportProductsCSV(w io.Writer) error {
+	products, err := s.productRepo.FindAllForExport()
+	if err != nil {
+		return err
+	}
+
+	categories, err := s.productRepo.FindAllCategory()
+	if err != nil {
+		return err
+	}
+	paths := categoryPaths(categories)
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(productCSVHeader); err != nil {
+		return err
+	}
+
+	for _, p := range products {
+		urls := make([]string, 0, len(p.Images))
+		for _, img := range p.Images {
+			urls = append(urls, img.URL)
+		}
+
+		record := []string{
+			p.ID.String(),
+			p.Name,
+			p.Description,
+			strconv.FormatInt(p.Price, 10),
+			strconv.Itoa(p.StockCount),
+			paths[p.CategoryID],
+			strings.Join(urls, imageURLSeparator),
+			strconv.FormatBool(p.IsActive),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// categoryPaths maps every category to its full "Parent > Child" path
+func categoryPaths(categories []models.Category) map[uuid.UUID]string {
+	byID := make(map[uuid.UUID]models.Category, len(categories))
+	for _, c := range categories {
+		byID[c.ID] = c
+	}
+
+	paths := make(map[uuid.UUID]string, len(categories))
+	for _, c := range categories {
+		parts := []string{c.Name}
+		seen := map[uuid.UUID]bool{c.ID: true}
+		for parent := c.ParentID; parent != nil && !seen[*parent]; {
+			p, ok := byID[*parent]
+			if !ok {
+				break
+			}
+			seen[p.ID] = true
+			parts = append([]string{p.Name}, parts...)
+			parent = p.ParentID
+		}
+		paths[c.ID] = strings.Join(parts, categoryPathSep)
+	}
+
+	return paths
+}
+
+// categoryResolver looks a category up by full path, or by name when the name is unique
+func categoryResolver(categories []models.Category) func(string) (uuid.UUID, error) {
+	byPath := make(map[string]uuid.UUID)
+	byName := make(map[string][]uuid.UUID)
+
+	for id, path := range categoryPaths(categories) {
+		byPath[strings.ToLower(path)] = id
+	}
+	for _, c := range categories {
+		key := strings.ToLower(c.Name)
+		byName[key] = append(byName[key], c.ID)
+	}
+
+	return func(value string) (uuid.UUID, error) {
+		key := strings.ToLower(strings.TrimSpace(value))
+		if id, ok := byPath[key]; ok {
+			return id, nil
+		}
+
+		switch ids := byName[key]; len(ids) {
+		case 0:
+			return uuid.Nil, fmt.Errorf("category %q not found", value)
+		case 1:
+			return ids[0], nil
+		default:
+			return uuid.Nil, fmt.Errorf("category %q is ambiguous, use the full path (e.g. Men > Running)", value)
+		}
+	}
+}
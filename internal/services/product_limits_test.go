@@ -0,0 +1,79 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/akhilnasimk/SS_backend/internal/dto"
+	"github.com/akhilnasimk/SS_backend/internal/models"
+)
+
+func TestApplyProductLimitsMaxPerCustomer(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  dto.ProductLimits
+		wantMax int // 0 for no cap
+		wantErr bool
+	}{
+		{name: "empty keeps the cap", wantMax: 3},
+		{name: "new cap", limits: dto.ProductLimits{MaxPerCustomer: "2"}, wantMax: 2},
+		{name: "spaces around the cap", limits: dto.ProductLimits{MaxPerCustomer: " 4 "}, wantMax: 4},
+		{name: "clear removes the cap", limits: dto.ProductLimits{ClearMaxPerCustomer: true}, wantMax: 0},
+		{name: "cap and clear", limits: dto.ProductLimits{MaxPerCustomer: "2", ClearMaxPerCustomer: true}, wantErr: true},
+		{name: "none is not a cap", limits: dto.ProductLimits{MaxPerCustomer: "none"}, wantErr: true},
+		{name: "zero cap", limits: dto.ProductLimits{MaxPerCustomer: "0"}, wantErr: true},
+		{name: "negative cap", limits: dto.ProductLimits{MaxPerCustomer: "-1"}, wantErr: true},
+		{name: "text cap", limits: dto.ProductLimits{MaxPerCustomer: "lots"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			max := 3
+			product := models.Product{MaxPerCustomer: &max}
+
+			err := applyProductLimits(&product, tt.limits)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyProductLimits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			gotMax := 0
+			if product.MaxPerCustomer != nil {
+				gotMax = *product.MaxPerCustomer
+			}
+			if gotMax != tt.wantMax {
+				t.Fatalf("MaxPerCustomer = %d, want %d", gotMax, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestApplyProductLimitsLowStockThreshold(t *testing.T) {
+	tests := []struct {
+		name          string
+		threshold     string
+		wantThreshold int
+		wantErr       bool
+	}{
+		{name: "empty keeps the threshold", threshold: "", wantThreshold: 5},
+		{name: "new threshold", threshold: "10", wantThreshold: 10},
+		{name: "zero alerts only when sold out", threshold: "0", wantThreshold: 0},
+		{name: "negative", threshold: "-1", wantErr: true},
+		{name: "text", threshold: "few", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			product := models.Product{LowStockThreshold: 5}
+
+			err := applyProductLimits(&product, dto.ProductLimits{LowStockThreshold: tt.threshold})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyProductLimits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && product.LowStockThreshold != tt.wantThreshold {
+				t.Fatalf("LowStockThreshold = %d, want %d", product.LowStockThreshold, tt.wantThreshold)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/dto"
+	"github.com/akhilnasimk/SS_backend/internal/models"
+)
+
+func TestParseScheduleTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    string // RFC 3339 in UTC, "" for nil
+		wantErr bool
+	}{
+		{name: "empty", value: "", want: ""},
+		{name: "blank", value: "   ", want: ""},
+		{name: "utc", value: "2025-11-28T10:00:00Z", want: "2025-11-28T10:00:00Z"},
+		{name: "offset is converted to utc", value: "2025-11-28T15:30:00+05:30", want: "2025-11-28T10:00:00Z"},
+		{name: "surrounding spaces", value: " 2025-11-28T10:00:00Z ", want: "2025-11-28T10:00:00Z"},
+		{name: "date only", value: "2025-11-28", wantErr: true},
+		{name: "garbage", value: "tomorrow", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseScheduleTime(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseScheduleTime(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if formatTime(got) != tt.want {
+				t.Fatalf("parseScheduleTime(%q) = %q, want %q", tt.value, formatTime(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyProductSchedule(t *testing.T) {
+	release := time.Date(2025, 11, 28, 10, 0, 0, 0, time.UTC)
+	ends := time.Date(2025, 11, 30, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		schedule    dto.ProductSchedule
+		wantRelease string
+		wantEnds    string
+		wantErr     bool
+	}{
+		{name: "empty keeps the window", wantRelease: "2025-11-28T10:00:00Z", wantEnds: "2025-11-30T10:00:00Z"},
+		{
+			name:        "clear one bound",
+			schedule:    dto.ProductSchedule{ClearEndsAt: true},
+			wantRelease: "2025-11-28T10:00:00Z",
+			wantEnds:    "",
+		},
+		{
+			name:        "clear both bounds",
+			schedule:    dto.ProductSchedule{ClearReleaseAt: true, ClearEndsAt: true},
+			wantRelease: "",
+			wantEnds:    "",
+		},
+		{
+			name:        "clear the end and move the release past it",
+			schedule:    dto.ProductSchedule{ReleaseAt: "2025-12-01T10:00:00Z", ClearEndsAt: true},
+			wantRelease: "2025-12-01T10:00:00Z",
+			wantEnds:    "",
+		},
+		{
+			name:        "new release",
+			schedule:    dto.ProductSchedule{ReleaseAt: "2025-11-29T10:00:00Z"},
+			wantRelease: "2025-11-29T10:00:00Z",
+			wantEnds:    "2025-11-30T10:00:00Z",
+		},
+		{name: "release after the kept end", schedule: dto.ProductSchedule{ReleaseAt: "2025-12-01T10:00:00Z"}, wantErr: true},
+		{name: "end equal to release", schedule: dto.ProductSchedule{EndsAt: "2025-11-28T10:00:00Z"}, wantErr: true},
+		{name: "invalid timestamp", schedule: dto.ProductSchedule{ReleaseAt: "soon"}, wantErr: true},
+		{name: "none is not a timestamp", schedule: dto.ProductSchedule{EndsAt: "none"}, wantErr: true},
+		{name: "time and clear", schedule: dto.ProductSchedule{EndsAt: "2025-12-01T10:00:00Z", ClearEndsAt: true}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, e := release, ends
+			product := models.Product{ReleaseAt: &r, EndsAt: &e}
+
+			err := applyProductSchedule(&product, tt.schedule)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyProductSchedule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				// a rejected schedule leaves the product alone
+				if !product.ReleaseAt.Equal(release) || !product.EndsAt.Equal(ends) {
+					t.Fatalf("applyProductSchedule() changed the window on error")
+				}
+				return
+			}
+			if got := formatTime(product.ReleaseAt); got != tt.wantRelease {
+				t.Errorf("ReleaseAt = %q, want %q", got, tt.wantRelease)
+			}
+			if got := formatTime(product.EndsAt); got != tt.wantEnds {
+				t.Errorf("EndsAt = %q, want %q", got, tt.wantEnds)
+			}
+		})
+	}
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
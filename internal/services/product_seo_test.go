@@ -0,0 +1,93 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/akhilnasimk/SS_backend/internal/dto"
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/akhilnasimk/SS_backend/utils/media"
+	"github.com/google/uuid"
+)
+
+func TestMetaField(t *testing.T) {
+	tests := []struct {
+		name    string
+		current string
+		value   string
+		clear   bool
+		want    string
+		wantErr bool
+	}{
+		{name: "empty keeps", current: "Air Max", value: "", want: "Air Max"},
+		{name: "blank keeps", current: "Air Max", value: "  ", want: "Air Max"},
+		{name: "clear removes", current: "Air Max", clear: true, want: ""},
+		{name: "new value", current: "Air Max", value: "Air Max 90", want: "Air Max 90"},
+		{name: "new value is trimmed", current: "", value: " Air Max 90 ", want: "Air Max 90"},
+		{name: "none is a plain value", current: "Air Max", value: "None", want: "None"},
+		{name: "value and clear", current: "Air Max", value: "Air Max 90", clear: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := metaField("meta title", tt.current, tt.value, tt.clear)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("metaField() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("metaField(%q, %q, %v) = %q, want %q", tt.current, tt.value, tt.clear, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateProductSlug(t *testing.T) {
+	useTestImageConfig(t)
+
+	tests := []struct {
+		name     string
+		rename   string
+		seo      dto.ProductSEO
+		wantSlug string
+		wantErr  bool
+	}{
+		{name: "plain edit keeps the slug", rename: "Air Max 90", wantSlug: "air-max"},
+		{name: "rename keeps the slug", rename: "Air Max 90 OG", wantSlug: "air-max"},
+		{name: "regenerate from the new name", rename: "Air Max 90 OG", seo: dto.ProductSEO{RegenerateSlug: true}, wantSlug: "air-max-90-og"},
+		{name: "explicit slug", rename: "Air Max 90", seo: dto.ProductSEO{Slug: "Air Max Infrared"}, wantSlug: "air-max-infrared"},
+		{name: "slug and regenerate", rename: "Air Max 90", seo: dto.ProductSEO{Slug: "am90", RegenerateSlug: true}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			product := &models.Product{
+				ID:        uuid.New(),
+				Name:      "Air Max 90",
+				Slug:      "air-max",
+				Price:     12000,
+				MetaTitle: "Air Max 90 | Sneaker Store",
+			}
+			repo := &stubProductsRepo{product: product}
+			svc := NewProductsService(repo, nil, media.NewMemoryStore(), nil)
+
+			req := dto.UpdateProductRequest{
+				Name:       tt.rename,
+				Price:      product.Price,
+				CategoryID: uuid.New().String(),
+				ProductSEO: tt.seo,
+			}
+			err := svc.UpdateProduct(product.ID, req, uuid.New())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UpdateProduct() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if repo.updated.Slug != tt.wantSlug {
+				t.Errorf("Slug = %q, want %q", repo.updated.Slug, tt.wantSlug)
+			}
+			if repo.updated.MetaTitle != product.MetaTitle {
+				t.Errorf("MetaTitle = %q, want it kept", repo.updated.MetaTitle)
+			}
+		})
+	}
+}
@@ -2,40 +2,75 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"mime/multipart"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/akhilnasimk/SS_backend/internal/config"
+	constent "github.com/akhilnasimk/SS_backend/internal/const"
 	"github.com/akhilnasimk/SS_backend/internal/dto"
+	"github.com/akhilnasimk/SS_backend/internal/enums"
 	"github.com/akhilnasimk/SS_backend/internal/helpers"
 	"github.com/akhilnasimk/SS_backend/internal/models"
 	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
-	"github.com/akhilnasimk/SS_backend/utils/cloudinary"
+	"github.com/akhilnasimk/SS_backend/utils/imaging"
+	"github.com/akhilnasimk/SS_backend/utils/media"
 	"github.com/google/uuid"
 )
 
 type ProductsService interface {
-	GetAllProducts(page, limit int, categoryID string, search string, minPrice, maxPrice int64, userRole string) ([]models.Product, int64, error)
-	GetProductById(idstring string) (dto.ProductResponse, error)
-	CreateProduct(name, description string, price int64, stockCount int, categoryID uuid.UUID, files []*multipart.FileHeader) (models.Product, error)
+	GetAllProducts(page, limit int, categoryID string, search string, minPrice, maxPrice int64, sort enums.ProductSort, userRole string) ([]models.Product, int64, dto.ProductFacets, error)
+	GetProductsByCursor(cursor string, limit int, categoryID string, search string, minPrice, maxPrice int64, sort enums.ProductSort, userRole string) ([]models.Product, string, *dto.ProductFacets, error)
+	GetProductById(idstring string, userRole string) (dto.ProductResponse, error)
+	GetProductBySlug(slug string, userRole string) (dto.ProductResponse, string, error)
+	GetUpcomingDrops(limit int) (dto.UpcomingDropsResponse, error)
+	CreateProduct(name, description string, price int64, stockCount int, categoryID uuid.UUID, files []*multipart.FileHeader, seo dto.ProductSEO, schedule dto.ProductSchedule, limits dto.ProductLimits, adminID uuid.UUID) (models.Product, error)
 	GetAllCategory() ([]dto.CategoryResponse, error)
-	UpdateProduct(id uuid.UUID, req dto.UpdateProductRequest) error
+	UpdateProduct(id uuid.UUID, req dto.UpdateProductRequest, adminID uuid.UUID) error
 	ToggleProductAvailability(idString string) error
 	DeleteProduct(idString string) error
+	RestoreProduct(idString string) error
+	GetTrash(page, limit int) ([]dto.TrashedProductResponse, int64, error)
+	PurgeTrash() (int64, error)
+	ReorderImages(productIDString string, imageIDs []string) ([]dto.ProductImageResponse, error)
+	SetPrimaryImage(productIDString, imageIDString string) ([]dto.ProductImageResponse, error)
+	UpdateImageAltText(productIDString, imageIDString, altText string) ([]dto.ProductImageResponse, error)
+	ImportProductsCSV(r io.Reader, dryRun bool, adminID uuid.UUID) (dto.ProductImportReport, error)
+	ExportProductsCSV(w io.Writer) error
+	GetVariants(productIDString string) ([]dto.ProductVariantResponse, error)
+	AddVariant(productIDString string, req dto.VariantRequest, adminID uuid.UUID) (dto.ProductVariantResponse, error)
+	UpdateVariant(productIDString, variantIDString string, req dto.VariantRequest, adminID uuid.UUID) (dto.ProductVariantResponse, error)
 }
 
 type productsService struct {
-	productRepo interfaces.ProductsRepository
+	productRepo  interfaces.ProductsRepository
+	redirectRepo interfaces.SlugRedirectRepository
+	media        media.MediaStore
+	wishlist     WishlistService
 }
 
-func NewProductsService(repo interfaces.ProductsRepository) ProductsService {
+func NewProductsService(repo interfaces.ProductsRepository, redirectRepo interfaces.SlugRedirectRepository, store media.MediaStore, wishlist WishlistService) ProductsService {
 	return &productsService{
-		productRepo: repo,
+		productRepo:  repo,
+		redirectRepo: redirectRepo,
+		media:        store,
+		wishlist:     wishlist,
 	}
 }
 
-func (s *productsService) GetAllProducts(page, limit int, categoryID string, search string, minPrice, maxPrice int64, userRole string) ([]models.Product, int64, error) {
+func (s *productsService) GetAllProducts(page, limit int, categoryID string, search string, minPrice, maxPrice int64, sort enums.ProductSort, userRole string) ([]models.Product, int64, dto.ProductFacets, error) {
+	if sort == "" {
+		sort = enums.SortNewest
+	}
+	if !sort.IsValid() {
+		return nil, 0, dto.ProductFacets{}, fmt.Errorf("invalid sort value: %s", sort)
+	}
+
 	if limit <= 0 {
 		limit = 10
 	}
@@ -48,15 +83,104 @@ func (s *productsService) GetAllProducts(page, limit int, categoryID string, sea
 	// Admin sees everything (including deleted), others see only active non-deleted
 	includeDeleted := userRole == "admin"
 
-	products, total, err := s.productRepo.GetAllProducts(limit, offset, categoryID, search, minPrice, maxPrice, includeDeleted)
+	products, total, err := s.productRepo.GetAllProducts(limit, offset, categoryID, search, minPrice, maxPrice, includeDeleted, sort)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, dto.ProductFacets{}, err
+	}
+	if err := s.applyStockHolds(products, userRole); err != nil {
+		return nil, 0, dto.ProductFacets{}, err
 	}
 
-	return products, total, nil
+	// Facets use the same filters so the counts match the listing
+	counts, err := s.productRepo.GetProductFacets(categoryID, search, minPrice, maxPrice, includeDeleted)
+	if err != nil {
+		return nil, 0, dto.ProductFacets{}, err
+	}
+
+	return products, total, toProductFacets(counts), nil
 }
 
-func (s *productsService) GetProductById(idstring string) (dto.ProductResponse, error) {
+// GetProductsByCursor is the keyset-paginated listing (newest first).
+// Facets are only computed for the first page.
+func (s *productsService) GetProductsByCursor(cursor string, limit int, categoryID string, search string, minPrice, maxPrice int64, sort enums.ProductSort, userRole string) ([]models.Product, string, *dto.ProductFacets, error) {
+	if sort != "" && sort != enums.SortNewest {
+		return nil, "", nil, fmt.Errorf("cursor pagination does not support sort=%s", sort)
+	}
+
+	after, err := parseKeyset(cursor)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	limit = clampLimit(limit)
+	includeDeleted := userRole == "admin"
+
+	// fetch one extra row to know if there is a next page
+	products, err := s.productRepo.GetProductsByCursor(limit+1, after, categoryID, search, minPrice, maxPrice, includeDeleted)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	products, hasMore := helpers.TrimPage(products, limit)
+	if err := s.applyStockHolds(products, userRole); err != nil {
+		return nil, "", nil, err
+	}
+	next := ""
+	if len(products) > 0 {
+		last := products[len(products)-1]
+		next = nextCursor(hasMore, last.CreatedAt, last.ID)
+	}
+
+	var facets *dto.ProductFacets
+	if after == nil {
+		counts, err := s.productRepo.GetProductFacets(categoryID, search, minPrice, maxPrice, includeDeleted)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		f := toProductFacets(counts)
+		facets = &f
+	}
+
+	return products, next, facets, nil
+}
+
+// maps repository facet counts to the response DTO
+func toProductFacets(counts interfaces.ProductFacetCounts) dto.ProductFacets {
+	facets := dto.ProductFacets{
+		Categories:   make([]dto.FacetBucket, 0, len(counts.Categories)),
+		PriceBuckets: make([]dto.FacetBucket, 0, len(counts.PriceBuckets)),
+		Availability: make([]dto.FacetBucket, 0, len(counts.Availability)),
+	}
+
+	for _, c := range counts.Categories {
+		facets.Categories = append(facets.Categories, dto.FacetBucket{Key: c.Key, Label: c.Label, Count: c.Count})
+	}
+
+	ranges := make(map[string]constent.PriceBucket, len(constent.PriceBuckets))
+	for _, pb := range constent.PriceBuckets {
+		ranges[pb.Key] = pb
+	}
+	for _, b := range counts.PriceBuckets {
+		bucket := dto.FacetBucket{Key: b.Key, Label: b.Label, Count: b.Count}
+		if pb, ok := ranges[b.Key]; ok {
+			min := pb.Min
+			bucket.Min = &min
+			if pb.Max > 0 {
+				max := pb.Max
+				bucket.Max = &max
+			}
+		}
+		facets.PriceBuckets = append(facets.PriceBuckets, bucket)
+	}
+
+	for _, a := range counts.Availability {
+		facets.Availability = append(facets.Availability, dto.FacetBucket{Key: a.Key, Label: a.Label, Count: a.Count})
+	}
+
+	return facets
+}
+
+func (s *productsService) GetProductById(idstring string, userRole string) (dto.ProductResponse, error) {
 	if idstring == "" {
 		return dto.ProductResponse{}, fmt.Errorf("didn't send the id")
 	}
@@ -67,12 +191,120 @@ func (s *productsService) GetProductById(idstring string) (dto.ProductResponse,
 		return dto.ProductResponse{}, err
 	}
 
+	if err := checkDropWindow(product, userRole); err != nil {
+		return dto.ProductResponse{}, err
+	}
+
+	products := []models.Product{product}
+	if err := s.applyStockHolds(products, userRole); err != nil {
+		return dto.ProductResponse{}, err
+	}
+	product = products[0]
+
 	// Map model to DTO
 	return dto.ToProductResponse(product), nil
 }
 
-// the service became soo big so i changed the cloudinary entire service to another file in util
-func (s *productsService) CreateProduct(name, description string, price int64, stockCount int, categoryID uuid.UUID, files []*multipart.FileHeader) (models.Product, error) {
+// GetProductBySlug resolves a current slug, or an old one: then the product's current slug is
+// returned as the redirect target and the response is empty
+func (s *productsService) GetProductBySlug(slug string, userRole string) (dto.ProductResponse, string, error) {
+	product, err := s.productRepo.ProductBySlug(slug)
+	if err == nil {
+		if err := checkDropWindow(product, userRole); err != nil {
+			return dto.ProductResponse{}, "", err
+		}
+
+		products := []models.Product{product}
+		if err := s.applyStockHolds(products, userRole); err != nil {
+			return dto.ProductResponse{}, "", err
+		}
+		return dto.ToProductResponse(products[0]), "", nil
+	}
+
+	redirect, rerr := s.redirectRepo.FindRedirect(constent.SlugEntityProduct, slug)
+	if rerr != nil {
+		return dto.ProductResponse{}, "", rerr
+	}
+	if redirect == nil {
+		return dto.ProductResponse{}, "", errors.New("product not found")
+	}
+
+	current, err := s.productRepo.FindById(redirect.EntityID)
+	if err != nil {
+		return dto.ProductResponse{}, "", errors.New("product not found")
+	}
+	return dto.ProductResponse{}, current.Slug, nil
+}
+
+// applyStockHolds turns StockCount (and variant stock) into what customers can still buy:
+// stock minus active checkout holds. Admins keep the real counts.
+func (s *productsService) applyStockHolds(products []models.Product, userRole string) error {
+	if userRole == "admin" || len(products) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(products))
+	for i, p := range products {
+		ids[i] = p.ID
+	}
+
+	byProduct, byVariant, err := s.productRepo.HeldStock(ids, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for i := range products {
+		p := &products[i]
+		p.StockCount = max(p.StockCount-byProduct[p.ID], 0)
+		for j := range p.Variants {
+			v := &p.Variants[j]
+			v.StockCount = max(v.StockCount-byVariant[v.ID], 0)
+		}
+	}
+	return nil
+}
+
+// checkDropWindow hides a timed drop from customers outside its release window; admins see everything
+func checkDropWindow(product models.Product, userRole string) error {
+	if userRole == "admin" {
+		return nil
+	}
+
+	switch product.DropStatus(time.Now()) {
+	case enums.DropUpcoming:
+		return errors.New("product is not released yet")
+	case enums.DropEnded:
+		return errors.New("product is no longer available")
+	}
+	return nil
+}
+
+// GetUpcomingDrops lists products releasing soon with their countdowns
+func (s *productsService) GetUpcomingDrops(limit int) (dto.UpcomingDropsResponse, error) {
+	now := time.Now()
+
+	products, err := s.productRepo.FindUpcoming(now, clampLimit(limit))
+	if err != nil {
+		return dto.UpcomingDropsResponse{}, err
+	}
+
+	resp := dto.UpcomingDropsResponse{
+		ServerTime: now,
+		Drops:      make([]dto.UpcomingDropResponse, 0, len(products)),
+	}
+	for _, p := range products {
+		resp.Drops = append(resp.Drops, dto.UpcomingDropResponse{
+			Product:         dto.ToProductResponse(p),
+			ReleaseAt:       *p.ReleaseAt,
+			StartsInSeconds: int64(p.ReleaseAt.Sub(now).Seconds()),
+		})
+	}
+
+	return resp, nil
+}
+
+// the service became soo big so i moved the upload logic to utils/media (MediaStore)
+func (s *productsService) CreateProduct(name, description string, price int64, stockCount int, categoryID uuid.UUID, files []*multipart.FileHeader, seo dto.ProductSEO, schedule dto.ProductSchedule, limits dto.ProductLimits, adminID uuid.UUID) (models.Product, error) {
 	// Set a reasonable timeout for the entire operation
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -85,33 +317,47 @@ func (s *productsService) CreateProduct(name, description string, price int64, s
 		StockCount:  stockCount,
 		CategoryID:  categoryID,
 		IsActive:    true,
+
+		LowStockThreshold: config.AppConfig.LowStockThreshold,
+	}
+
+	if err := applyProductSEO(s.productRepo, &product, seo); err != nil {
+		return models.Product{}, err
+	}
+	if err := applyProductSchedule(&product, schedule); err != nil {
+		return models.Product{}, err
+	}
+	if err := applyProductLimits(&product, limits); err != nil {
+		return models.Product{}, err
+	}
+
+	if err := validateImages(files); err != nil {
+		return models.Product{}, err
 	}
 
-	// Upload images to Cloudinary
-	opts := cloudinary.DefaultUploadOptions("products")
-	uploadResults, err := cloudinary.UploadMultiple(ctx, files, opts)
+	// Upload images to the media store
+	opts := media.DefaultUploadOptions("products")
+	uploadResults, err := media.UploadMultiple(ctx, s.media, files, opts)
 	if err != nil {
 		return models.Product{}, fmt.Errorf("failed to upload images: %w", err)
 	}
 
 	// Convert upload results to ProductImage models
+	// upload order is the initial display order, the first image is primary
 	var images []models.ProductImage
-	for _, result := range uploadResults {
-		images = append(images, models.ProductImage{
-			URL:     result.URL,
-			AltText: result.AltText,
-		})
+	for i, result := range uploadResults {
+		images = append(images, imageFromUpload(result, i))
 	}
 
 	// Save to database
-	createdProduct, err := s.productRepo.CreateProductWithImages(product, images)
+	createdProduct, err := s.productRepo.CreateProductWithImages(product, images, adminID)
 	if err != nil {
-		// Rollback: delete uploaded images from Cloudinary
+		// Rollback: delete uploaded images from the media store
 		var uploadedURLs []string
 		for _, img := range images {
-			uploadedURLs = append(uploadedURLs, img.URL)
+			uploadedURLs = append(uploadedURLs, img.AllURLs()...)
 		}
-		cloudinary.DeleteMultipleAsync(uploadedURLs)
+		media.DeleteMultipleAsync(s.media, uploadedURLs)
 		return models.Product{}, fmt.Errorf("failed to save product: %w", err)
 	}
 
@@ -130,28 +376,56 @@ func (s *productsService) GetAllCategory() ([]dto.CategoryResponse, error) {
 	resp = make([]dto.CategoryResponse, 0, len(categories)) // allocate properly
 
 	for _, c := range categories {
-		resp = append(resp, dto.CategoryResponse{
-			ID:        c.ID,
-			Name:      c.Name,
-			CreatedAt: c.CreatedAt,
-		})
+		resp = append(resp, dto.ToCategoryResponse(c))
 	}
 
 	return resp, nil
 }
 
-func (s *productsService) UpdateProduct(id uuid.UUID, req dto.UpdateProductRequest) error {
+func (s *productsService) UpdateProduct(id uuid.UUID, req dto.UpdateProductRequest, adminID uuid.UUID) error {
 	//  Fetch existing product
 	product, err := s.productRepo.FindById(id)
 	if err != nil {
 		return fmt.Errorf("product not found: %w", err)
 	}
 
+	// Reject bad uploads before touching the existing images
+	if err := validateImages(req.NewImages); err != nil {
+		return err
+	}
+
+	// keep the current slug unless a new one is set or it is regenerated from the name
+	if req.RegenerateSlug && strings.TrimSpace(req.Slug) != "" {
+		return errors.New("invalid slug: set a slug or regenerate_slug, not both")
+	}
+	if req.Slug == "" && !req.RegenerateSlug {
+		req.Slug = product.Slug
+	}
+
 	// Update basic fields
+	oldPrice := product.Price
 	product.Name = req.Name
 	product.Description = req.Description
 	product.Price = req.Price
-	product.StockCount = req.StockCount
+
+	if err := applyProductSEO(s.productRepo, product, req.ProductSEO); err != nil {
+		return err
+	}
+	if err := applyProductSchedule(product, req.ProductSchedule); err != nil {
+		return err
+	}
+	if err := applyProductLimits(product, req.ProductLimits); err != nil {
+		return err
+	}
+
+	// With active variants the product stock is the sum of their stock
+	variants, err := s.productRepo.FindVariantsByProduct(product.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load variants: %w", err)
+	}
+	if !hasActiveVariant(variants) {
+		product.StockCount = req.StockCount
+	}
 
 	catID, err := uuid.Parse(req.CategoryID)
 	if err != nil {
@@ -188,10 +462,10 @@ func (s *productsService) UpdateProduct(id uuid.UUID, req dto.UpdateProductReque
 			product.Images = filtered
 		}
 
-		// Delete from Cloudinary (async, fire-and-forget)
+		// Delete from the media store (async, fire-and-forget)
 		if len(removedURLs) > 0 {
-			log.Printf("Deleting %d images from Cloudinary for product %s", len(removedURLs), product.ID)
-			cloudinary.DeleteMultipleAsync(removedURLs)
+			log.Printf("Deleting %d images from media storage for product %s", len(removedURLs), product.ID)
+			media.DeleteMultipleAsync(s.media, removedURLs)
 		}
 	}
 
@@ -200,24 +474,32 @@ func (s *productsService) UpdateProduct(id uuid.UUID, req dto.UpdateProductReque
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
-		results, err := cloudinary.UploadMultiple(
+		results, err := media.UploadMultiple(
 			ctx,
+			s.media,
 			req.NewImages,
-			cloudinary.DefaultUploadOptions("products"),
+			media.DefaultUploadOptions("products"),
 		)
 
 		if err != nil {
 			return fmt.Errorf("failed to upload new images: %w", err)
 		}
 
+		// New uploads go after the images that are kept
+		next := 0
+		for _, img := range product.Images {
+			if img.Priority >= next {
+				next = img.Priority + 1
+			}
+		}
+
 		// Add successfully uploaded images to product
 		for _, img := range results {
 			if img.Error == nil {
-				product.Images = append(product.Images, models.ProductImage{
-					URL:       img.URL,
-					AltText:   img.AltText,
-					ProductID: product.ID,
-				})
+				image := imageFromUpload(img, next)
+				image.ProductID = product.ID
+				product.Images = append(product.Images, image)
+				next++
 			} else {
 				log.Printf("Failed to upload image %s: %v", img.AltText, img.Error)
 			}
@@ -225,10 +507,15 @@ func (s *productsService) UpdateProduct(id uuid.UUID, req dto.UpdateProductReque
 	}
 
 	// Save everything to database
-	if err := s.productRepo.UpdateProduct(product); err != nil {
+	if err := s.productRepo.UpdateProduct(product, adminID); err != nil {
 		return fmt.Errorf("failed to update product: %w", err)
 	}
 
+	// Tell wishlisters about a price cut
+	if product.Price < oldPrice {
+		go s.wishlist.NotifyPriceDrop(product.ID, product.Name, oldPrice, product.Price)
+	}
+
 	return nil
 }
 
@@ -257,3 +544,407 @@ func (s *productsService) DeleteProduct(idString string) error {
 
 	return nil
 }
+
+func (s *productsService) RestoreProduct(idString string) error {
+	id, err := uuid.Parse(idString)
+	if err != nil {
+		return fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	return s.productRepo.RestoreProduct(id)
+}
+
+// trashRetention is how long a deleted product stays restorable
+func trashRetention() time.Duration {
+	return time.Duration(config.AppConfig.TrashRetentionDays) * 24 * time.Hour
+}
+
+func (s *productsService) GetTrash(page, limit int) ([]dto.TrashedProductResponse, int64, error) {
+	limit = clampLimit(limit)
+	if page <= 0 {
+		page = 1
+	}
+
+	products, total, err := s.productRepo.FindDeletedProducts(limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	resp := make([]dto.TrashedProductResponse, 0, len(products))
+	for _, p := range products {
+		resp = append(resp, dto.ToTrashedProductResponse(p, trashRetention()))
+	}
+
+	return resp, total, nil
+}
+
+// PurgeTrash hard-deletes products past the retention period and removes their images from the media store
+func (s *productsService) PurgeTrash() (int64, error) {
+	purged, urls, err := s.productRepo.PurgeDeletedBefore(time.Now().Add(-trashRetention()))
+	if err != nil {
+		return 0, err
+	}
+
+	media.DeleteMultipleAsync(s.media, urls)
+
+	return purged, nil
+}
+
+// ---------------- images ----------------
+
+// validateImages checks every file before anything is uploaded
+func validateImages(files []*multipart.FileHeader) error {
+	rules := imaging.Rules{
+		MaxBytes:  int64(config.AppConfig.ImageMaxBytes),
+		MinWidth:  config.AppConfig.ImageMinWidth,
+		MinHeight: config.AppConfig.ImageMinHeight,
+		MaxWidth:  config.AppConfig.ImageMaxWidth,
+		MaxHeight: config.AppConfig.ImageMaxHeight,
+	}
+
+	for _, f := range files {
+		if err := imaging.Validate(f, rules); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// imageFromUpload maps an uploaded original and its derivatives to a ProductImage
+func imageFromUpload(result media.UploadResult, priority int) models.ProductImage {
+	return models.ProductImage{
+		URL:          result.URL,
+		ThumbnailURL: result.Sizes[constent.ImageSizeThumbnail],
+		CardURL:      result.Sizes[constent.ImageSizeCard],
+		ZoomURL:      result.Sizes[constent.ImageSizeZoom],
+		AltText:      result.AltText,
+		Priority:     priority,
+	}
+}
+
+func (s *productsService) ReorderImages(productIDString string, imageIDs []string) ([]dto.ProductImageResponse, error) {
+	productID, err := uuid.Parse(productIDString)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(imageIDs))
+	for _, raw := range imageIDs {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid image ID: %s", raw)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := s.productRepo.ReorderImages(productID, ids); err != nil {
+		return nil, err
+	}
+
+	return s.productImages(productID)
+}
+
+func (s *productsService) SetPrimaryImage(productIDString, imageIDString string) ([]dto.ProductImageResponse, error) {
+	productID, err := uuid.Parse(productIDString)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	imageID, err := uuid.Parse(imageIDString)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image ID: %w", err)
+	}
+
+	if err := s.productRepo.SetPrimaryImage(productID, imageID); err != nil {
+		return nil, err
+	}
+
+	return s.productImages(productID)
+}
+
+func (s *productsService) UpdateImageAltText(productIDString, imageIDString, altText string) ([]dto.ProductImageResponse, error) {
+	productID, err := uuid.Parse(productIDString)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	imageID, err := uuid.Parse(imageIDString)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image ID: %w", err)
+	}
+
+	altText = strings.TrimSpace(altText)
+	if altText == "" {
+		return nil, fmt.Errorf("invalid alt text: cannot be empty")
+	}
+
+	if err := s.productRepo.UpdateImageAltText(productID, imageID, altText); err != nil {
+		return nil, err
+	}
+
+	return s.productImages(productID)
+}
+
+// productImages returns the product's images in display order
+func (s *productsService) productImages(productID uuid.UUID) ([]dto.ProductImageResponse, error) {
+	images, err := s.productRepo.FindImages(productID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToProductImageResponses(images), nil
+}
+
+// ---------------- variants ----------------
+
+// hasActiveVariant reports whether any size is on sale. Without one the product is sold, and
+// its stock edited, at product level, like the cart and order paths do.
+func hasActiveVariant(variants []models.ProductVariant) bool {
+	for _, v := range variants {
+		if v.IsActive {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *productsService) GetVariants(productIDString string) ([]dto.ProductVariantResponse, error) {
+	productID, err := uuid.Parse(productIDString)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	product, err := s.productRepo.FindById(productID)
+	if err != nil {
+		return nil, fmt.Errorf("product not found: %w", err)
+	}
+
+	variants, err := s.productRepo.FindVariantsByProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]dto.ProductVariantResponse, 0, len(variants))
+	for _, v := range variants {
+		resp = append(resp, dto.ToProductVariantResponse(v, product.Price))
+	}
+
+	return resp, nil
+}
+
+func (s *productsService) AddVariant(productIDString string, req dto.VariantRequest, adminID uuid.UUID) (dto.ProductVariantResponse, error) {
+	productID, err := uuid.Parse(productIDString)
+	if err != nil {
+		return dto.ProductVariantResponse{}, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	product, err := s.productRepo.FindById(productID)
+	if err != nil {
+		return dto.ProductVariantResponse{}, fmt.Errorf("product not found: %w", err)
+	}
+
+	variant := models.ProductVariant{
+		ProductID:     productID,
+		Size:          strings.TrimSpace(req.Size),
+		Colorway:      strings.TrimSpace(req.Colorway),
+		SKU:           strings.ToUpper(strings.TrimSpace(req.SKU)),
+		StockCount:    req.StockCount,
+		PriceOverride: req.PriceOverride,
+		IsActive:      true,
+
+		MaxPerCustomer: req.MaxPerCustomer,
+	}
+	if req.IsActive != nil {
+		variant.IsActive = *req.IsActive
+	}
+
+	if err := s.productRepo.CreateVariant(&variant, adminID); err != nil {
+		return dto.ProductVariantResponse{}, err
+	}
+
+	return dto.ToProductVariantResponse(variant, product.Price), nil
+}
+
+func (s *productsService) UpdateVariant(productIDString, variantIDString string, req dto.VariantRequest, adminID uuid.UUID) (dto.ProductVariantResponse, error) {
+	productID, err := uuid.Parse(productIDString)
+	if err != nil {
+		return dto.ProductVariantResponse{}, fmt.Errorf("invalid product ID: %w", err)
+	}
+	variantID, err := uuid.Parse(variantIDString)
+	if err != nil {
+		return dto.ProductVariantResponse{}, fmt.Errorf("invalid variant ID: %w", err)
+	}
+
+	product, err := s.productRepo.FindById(productID)
+	if err != nil {
+		return dto.ProductVariantResponse{}, fmt.Errorf("product not found: %w", err)
+	}
+
+	variant, err := s.productRepo.FindVariantByID(productID, variantID)
+	if err != nil {
+		return dto.ProductVariantResponse{}, err
+	}
+
+	variant.Size = strings.TrimSpace(req.Size)
+	variant.Colorway = strings.TrimSpace(req.Colorway)
+	variant.SKU = strings.ToUpper(strings.TrimSpace(req.SKU))
+	variant.StockCount = req.StockCount
+	variant.PriceOverride = req.PriceOverride
+	variant.MaxPerCustomer = req.MaxPerCustomer
+	if req.IsActive != nil {
+		variant.IsActive = *req.IsActive
+	}
+
+	if err := s.productRepo.UpdateVariant(variant, adminID); err != nil {
+		return dto.ProductVariantResponse{}, err
+	}
+
+	return dto.ToProductVariantResponse(*variant, product.Price), nil
+}
+
+// applyProductSEO sets the meta fields and a unique slug (requested, or generated from the name).
+// Empty meta fields keep their current value, the clear flags remove it.
+func applyProductSEO(repo interfaces.ProductsRepository, product *models.Product, seo dto.ProductSEO) error {
+	metaTitle, err := metaField("meta title", product.MetaTitle, seo.MetaTitle, seo.ClearMetaTitle)
+	if err != nil {
+		return err
+	}
+	metaDescription, err := metaField("meta description", product.MetaDescription, seo.MetaDescription, seo.ClearMetaDescription)
+	if err != nil {
+		return err
+	}
+	if len(metaTitle) > 150 {
+		return errors.New("invalid meta title: at most 150 characters")
+	}
+	if len(metaDescription) > 320 {
+		return errors.New("invalid meta description: at most 320 characters")
+	}
+
+	slug, err := uniqueProductSlug(repo, seo.Slug, product.Name, product.ID)
+	if err != nil {
+		return err
+	}
+
+	product.Slug = slug
+	product.MetaTitle = metaTitle
+	product.MetaDescription = metaDescription
+	return nil
+}
+
+// metaField resolves one meta field against its current value: empty keeps it, clear removes it
+func metaField(name, current, value string, clear bool) (string, error) {
+	switch value = strings.TrimSpace(value); {
+	case clear && value != "":
+		return "", fmt.Errorf("invalid %s: set a value or clear it, not both", name)
+	case clear:
+		return "", nil
+	case value == "":
+		return current, nil
+	default:
+		return value, nil
+	}
+}
+
+// uniqueProductSlug slugifies the requested slug (or the name) and appends -2, -3... until it is free
+func uniqueProductSlug(repo interfaces.ProductsRepository, requested, name string, id uuid.UUID) (string, error) {
+	base := helpers.Slugify(requested)
+	if base == "" {
+		base = helpers.Slugify(name)
+	}
+	if base == "" {
+		return "", errors.New("invalid slug: product name must contain letters or digits")
+	}
+	if runes := []rune(base); len(runes) > 240 {
+		base = strings.TrimRight(string(runes[:240]), "-")
+	}
+
+	slug := base
+	for n := 2; ; n++ {
+		exists, err := repo.SlugExists(slug, id)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return slug, nil
+		}
+		slug = fmt.Sprintf("%s-%d", base, n)
+	}
+}
+
+// applyProductSchedule parses the drop window; empty fields keep the current value, the clear flags remove it
+func applyProductSchedule(product *models.Product, schedule dto.ProductSchedule) error {
+	releaseAt, err := scheduleField(product.ReleaseAt, schedule.ReleaseAt, schedule.ClearReleaseAt)
+	if err != nil {
+		return fmt.Errorf("invalid schedule: release_at %w", err)
+	}
+	endsAt, err := scheduleField(product.EndsAt, schedule.EndsAt, schedule.ClearEndsAt)
+	if err != nil {
+		return fmt.Errorf("invalid schedule: ends_at %w", err)
+	}
+
+	if releaseAt != nil && endsAt != nil && !endsAt.After(*releaseAt) {
+		return errors.New("invalid schedule: ends_at must be after release_at")
+	}
+
+	product.ReleaseAt = releaseAt
+	product.EndsAt = endsAt
+	return nil
+}
+
+// scheduleField resolves one drop window bound against its current value
+func scheduleField(current *time.Time, value string, clear bool) (*time.Time, error) {
+	switch value = strings.TrimSpace(value); {
+	case clear && value != "":
+		return nil, errors.New("set a time or clear it, not both")
+	case clear:
+		return nil, nil
+	case value == "":
+		return current, nil
+	default:
+		return parseScheduleTime(value)
+	}
+}
+
+func parseScheduleTime(value string) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, errors.New("must be an RFC 3339 timestamp, e.g. 2025-11-28T10:00:00Z")
+	}
+	t = t.UTC()
+	return &t, nil
+}
+
+// applyProductLimits parses the per-customer cap and the low-stock threshold. Empty keeps the
+// current value, clear_max_per_customer removes the cap.
+func applyProductLimits(product *models.Product, limits dto.ProductLimits) error {
+	if threshold := strings.TrimSpace(limits.LowStockThreshold); threshold != "" {
+		n, err := strconv.Atoi(threshold)
+		if err != nil || n < 0 {
+			return errors.New("invalid low_stock_threshold: must be zero or a positive whole number")
+		}
+		product.LowStockThreshold = n
+	}
+
+	value := strings.TrimSpace(limits.MaxPerCustomer)
+	if limits.ClearMaxPerCustomer {
+		if value != "" {
+			return errors.New("invalid max_per_customer: set a cap or clear it, not both")
+		}
+		product.MaxPerCustomer = nil
+		return nil
+	}
+	if value == "" {
+		return nil
+	}
+
+	max, err := strconv.Atoi(value)
+	if err != nil || max <= 0 {
+		return errors.New("invalid max_per_customer: must be a positive whole number")
+	}
+	product.MaxPerCustomer = &max
+	return nil
+}
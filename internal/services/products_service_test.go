@@ -0,0 +1,264 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"mime/multipart"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/config"
+	"github.com/akhilnasimk/SS_backend/internal/dto"
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
+	"github.com/akhilnasimk/SS_backend/utils/media"
+	"github.com/google/uuid"
+)
+
+// stubProductsRepo serves one product and records what is saved; anything else the test reaches panics
+type stubProductsRepo struct {
+	interfaces.ProductsRepository
+	saveErr error
+	created models.Product
+	images  []models.ProductImage
+
+	product  *models.Product
+	variants []models.ProductVariant
+	updated  models.Product
+}
+
+func (r *stubProductsRepo) SlugExists(slug string, excludeID uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+func (r *stubProductsRepo) CreateProductWithImages(product models.Product, images []models.ProductImage, actorID uuid.UUID) (models.Product, error) {
+	if r.saveErr != nil {
+		return models.Product{}, r.saveErr
+	}
+	product.ID = uuid.New()
+	r.created = product
+	r.images = images
+	return product, nil
+}
+
+func (r *stubProductsRepo) FindById(id uuid.UUID) (*models.Product, error) {
+	if r.product == nil || r.product.ID != id {
+		return nil, errors.New("record not found")
+	}
+	product := *r.product
+	return &product, nil
+}
+
+func (r *stubProductsRepo) FindVariantsByProduct(productID uuid.UUID) ([]models.ProductVariant, error) {
+	return r.variants, nil
+}
+
+func (r *stubProductsRepo) UpdateProduct(product *models.Product, actorID uuid.UUID) error {
+	r.updated = *product
+	return nil
+}
+
+func TestUpdateProductStockFollowsActiveVariants(t *testing.T) {
+	useTestImageConfig(t)
+
+	tests := []struct {
+		name      string
+		variants  []models.ProductVariant
+		wantStock int
+	}{
+		{name: "no variants", wantStock: 7},
+		{name: "only inactive variants", variants: []models.ProductVariant{{IsActive: false, StockCount: 3}}, wantStock: 7},
+		{name: "an active variant", variants: []models.ProductVariant{{IsActive: true, StockCount: 3}}, wantStock: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			product := &models.Product{ID: uuid.New(), Name: "Air Max 90", Slug: "air-max-90", Price: 12000, StockCount: 3}
+			repo := &stubProductsRepo{product: product, variants: tt.variants}
+			svc := NewProductsService(repo, nil, media.NewMemoryStore(), nil)
+
+			req := dto.UpdateProductRequest{
+				Name:       product.Name,
+				Price:      product.Price,
+				StockCount: 7,
+				CategoryID: uuid.New().String(),
+			}
+			if err := svc.UpdateProduct(product.ID, req, uuid.New()); err != nil {
+				t.Fatalf("UpdateProduct() error = %v", err)
+			}
+			if repo.updated.StockCount != tt.wantStock {
+				t.Fatalf("StockCount = %d, want %d", repo.updated.StockCount, tt.wantStock)
+			}
+		})
+	}
+}
+
+func TestCreateProductUploadsToMediaStore(t *testing.T) {
+	useTestImageConfig(t)
+
+	store := media.NewMemoryStore()
+	repo := &stubProductsRepo{}
+	svc := NewProductsService(repo, nil, store, nil)
+
+	files := pngUploads(t, []string{"front.png", "side.png"}, 800, 600)
+
+	product, err := svc.CreateProduct("Air Max 90", "classic", 12000, 4, uuid.New(), files,
+		dto.ProductSEO{}, dto.ProductSchedule{}, dto.ProductLimits{}, uuid.New())
+	if err != nil {
+		t.Fatalf("CreateProduct() error = %v", err)
+	}
+
+	if product.Slug != "air-max-90" {
+		t.Errorf("Slug = %q, want %q", product.Slug, "air-max-90")
+	}
+	if !product.IsActive {
+		t.Errorf("IsActive = false, new products start active")
+	}
+	if product.LowStockThreshold != 5 {
+		t.Errorf("LowStockThreshold = %d, want the configured 5", product.LowStockThreshold)
+	}
+
+	if len(repo.images) != 2 {
+		t.Fatalf("saved %d images, want 2", len(repo.images))
+	}
+	// every upload stores the original plus its thumbnail, card and zoom sizes
+	if store.Len() != 8 {
+		t.Fatalf("store holds %d files, want 8", store.Len())
+	}
+
+	// upload order is display order, the first file is the primary image
+	for i, want := range []string{"front.png", "side.png"} {
+		if img := repo.images[i]; img.AltText != want || img.Priority != i {
+			t.Errorf("image %d = %s priority %d, want %s priority %d", i, img.AltText, img.Priority, want, i)
+		}
+	}
+
+	for _, img := range repo.images {
+		for _, url := range []string{img.URL, img.ThumbnailURL, img.CardURL, img.ZoomURL} {
+			if url == "" {
+				t.Fatalf("image %s is missing a size: %+v", img.AltText, img)
+			}
+			if _, ok := store.Get(url); !ok {
+				t.Errorf("image url %s is not in the store", url)
+			}
+		}
+	}
+	card, _ := store.Get(repo.images[0].CardURL)
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(card))
+	if err != nil {
+		t.Fatalf("card image does not decode: %v", err)
+	}
+	if format != "jpeg" || cfg.Width != 600 || cfg.Height != 450 {
+		t.Errorf("card image = %s %dx%d, want jpeg 600x450", format, cfg.Width, cfg.Height)
+	}
+}
+
+func TestCreateProductRejectsImagesBeforeUpload(t *testing.T) {
+	useTestImageConfig(t)
+
+	tests := []struct {
+		name          string
+		width, height int
+		wantErr       string
+	}{
+		{name: "too small", width: 400, height: 600, wantErr: "below the minimum"},
+		{name: "too large", width: 5000, height: 600, wantErr: "above the maximum"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := media.NewMemoryStore()
+			repo := &stubProductsRepo{}
+			svc := NewProductsService(repo, nil, store, nil)
+
+			files := pngUploads(t, []string{"shoe.png"}, tt.width, tt.height)
+
+			_, err := svc.CreateProduct("Air Max 90", "", 12000, 4, uuid.New(), files,
+				dto.ProductSEO{}, dto.ProductSchedule{}, dto.ProductLimits{}, uuid.New())
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("CreateProduct() error = %v, want %q", err, tt.wantErr)
+			}
+			if store.Len() != 0 {
+				t.Errorf("store holds %d files, want nothing uploaded", store.Len())
+			}
+		})
+	}
+}
+
+func TestCreateProductRemovesUploadsWhenSaveFails(t *testing.T) {
+	useTestImageConfig(t)
+
+	store := media.NewMemoryStore()
+	repo := &stubProductsRepo{saveErr: errors.New("db down")}
+	svc := NewProductsService(repo, nil, store, nil)
+
+	files := pngUploads(t, []string{"front.png"}, 800, 600)
+
+	_, err := svc.CreateProduct("Air Max 90", "", 12000, 4, uuid.New(), files,
+		dto.ProductSEO{}, dto.ProductSchedule{}, dto.ProductLimits{}, uuid.New())
+	if err == nil || !strings.Contains(err.Error(), "failed to save product") {
+		t.Fatalf("CreateProduct() error = %v, want a save failure", err)
+	}
+
+	// uploads are removed in the background
+	deadline := time.Now().Add(2 * time.Second)
+	for store.Len() > 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if store.Len() != 0 {
+		t.Fatalf("store still holds %d files after the failed save", store.Len())
+	}
+}
+
+// useTestImageConfig swaps in the config the upload path reads
+func useTestImageConfig(t *testing.T) {
+	t.Helper()
+
+	previous := config.AppConfig
+	config.AppConfig = &config.Config{
+		ImageMaxBytes:     8 << 20,
+		ImageMinWidth:     500,
+		ImageMinHeight:    500,
+		ImageMaxWidth:     4000,
+		ImageMaxHeight:    4000,
+		LowStockThreshold: 5,
+	}
+	t.Cleanup(func() { config.AppConfig = previous })
+}
+
+// pngUploads builds multipart file headers the way gin hands them to the service
+func pngUploads(t *testing.T, names []string, width, height int) []*multipart.FileHeader {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		img.Set(x, height/2, color.RGBA{R: 200, A: 255})
+	}
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, img); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, name := range names {
+		part, err := writer.CreateFormFile("images", name)
+		if err != nil {
+			t.Fatalf("create form file: %v", err)
+		}
+		part.Write(encoded.Bytes())
+	}
+	writer.Close()
+
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(32 << 20)
+	if err != nil {
+		t.Fatalf("read form: %v", err)
+	}
+	t.Cleanup(func() { form.RemoveAll() })
+
+	return form.File["images"]
+}
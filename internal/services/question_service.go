@@ -0,0 +1,179 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/akhilnasimk/SS_backend/internal/dto"
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
+	"github.com/google/uuid"
+)
+
+type QuestionService interface {
+	GetProductQuestions(productIDString string, page, limit int) (dto.QuestionListResponse, error)
+	AskQuestion(userIDString, productIDString string, req dto.QuestionRequest) (dto.QuestionResponse, error)
+	AnswerQuestion(userIDString, userRole, productIDString, questionIDString string, req dto.AnswerRequest) (dto.QuestionResponse, error)
+}
+
+type questionService struct {
+	questionRepo interfaces.QuestionRepository
+	orderRepo    interfaces.OrderRepository
+	productRepo  interfaces.ProductsRepository
+	emailService EmailService
+}
+
+func NewQuestionService(questionRepo interfaces.QuestionRepository, orderRepo interfaces.OrderRepository, productRepo interfaces.ProductsRepository, emailService EmailService) QuestionService {
+	return &questionService{
+		questionRepo: questionRepo,
+		orderRepo:    orderRepo,
+		productRepo:  productRepo,
+		emailService: emailService,
+	}
+}
+
+func (s *questionService) GetProductQuestions(productIDString string, page, limit int) (dto.QuestionListResponse, error) {
+	productID, err := uuid.Parse(productIDString)
+	if err != nil {
+		return dto.QuestionListResponse{}, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	if _, err := s.productRepo.FindById(productID); err != nil {
+		return dto.QuestionListResponse{}, errors.New("product not found")
+	}
+
+	limit = clampLimit(limit)
+	if page <= 0 {
+		page = 1
+	}
+
+	questions, total, err := s.questionRepo.FindByProduct(productID, limit, (page-1)*limit)
+	if err != nil {
+		return dto.QuestionListResponse{}, err
+	}
+
+	resp := dto.QuestionListResponse{
+		Questions: make([]dto.QuestionResponse, 0, len(questions)),
+		Total:     total,
+		Page:      page,
+		Limit:     limit,
+	}
+	for _, q := range questions {
+		resp.Questions = append(resp.Questions, dto.ToQuestionResponse(q))
+	}
+
+	return resp, nil
+}
+
+func (s *questionService) AskQuestion(userIDString, productIDString string, req dto.QuestionRequest) (dto.QuestionResponse, error) {
+	userID, productID, err := parseReviewIDs(userIDString, productIDString)
+	if err != nil {
+		return dto.QuestionResponse{}, err
+	}
+
+	if _, err := s.productRepo.FindById(productID); err != nil {
+		return dto.QuestionResponse{}, errors.New("product not found")
+	}
+
+	question := models.ProductQuestion{
+		ProductID: productID,
+		UserID:    userID,
+		Body:      strings.TrimSpace(req.Body),
+	}
+
+	if err := s.questionRepo.CreateQuestion(&question); err != nil {
+		return dto.QuestionResponse{}, fmt.Errorf("failed to save question: %w", err)
+	}
+
+	return s.questionResponse(question.ID)
+}
+
+// AnswerQuestion accepts answers from admins and from users with a delivered purchase of the product,
+// then emails the asker in the background
+func (s *questionService) AnswerQuestion(userIDString, userRole, productIDString, questionIDString string, req dto.AnswerRequest) (dto.QuestionResponse, error) {
+	userID, productID, err := parseReviewIDs(userIDString, productIDString)
+	if err != nil {
+		return dto.QuestionResponse{}, err
+	}
+
+	questionID, err := uuid.Parse(questionIDString)
+	if err != nil {
+		return dto.QuestionResponse{}, fmt.Errorf("invalid question ID: %w", err)
+	}
+
+	question, err := s.questionRepo.FindQuestionByID(questionID)
+	if err != nil {
+		return dto.QuestionResponse{}, err
+	}
+	if question.ProductID != productID {
+		return dto.QuestionResponse{}, errors.New("question not found")
+	}
+
+	answer := models.ProductAnswer{
+		QuestionID: question.ID,
+		UserID:     userID,
+		Body:       strings.TrimSpace(req.Body),
+		IsAdmin:    userRole == "admin",
+	}
+
+	if !answer.IsAdmin {
+		verified, err := s.orderRepo.HasDeliveredPurchase(userID, productID)
+		if err != nil {
+			return dto.QuestionResponse{}, err
+		}
+		if !verified {
+			return dto.QuestionResponse{}, errors.New("only admins or verified buyers can answer questions on this product")
+		}
+		answer.IsVerifiedBuyer = true
+	}
+
+	if err := s.questionRepo.CreateAnswer(&answer); err != nil {
+		return dto.QuestionResponse{}, fmt.Errorf("failed to save answer: %w", err)
+	}
+
+	if question.UserID != userID && question.User != nil {
+		s.notifyAsker(*question, answer)
+	}
+
+	return s.questionResponse(question.ID)
+}
+
+// notifyAsker sends the "your question was answered" email without blocking the request
+func (s *questionService) notifyAsker(question models.ProductQuestion, answer models.ProductAnswer) {
+	productName := "a product"
+	if product, err := s.productRepo.FindById(question.ProductID); err == nil {
+		productName = product.Name
+	}
+
+	to := question.User.Email
+	subject := fmt.Sprintf("Your question about %s has been answered", productName)
+	body := fmt.Sprintf(`
+		<html>
+		<body>
+			<p>Hello %s,</p>
+			<p>You asked about <b>%s</b>:</p>
+			<blockquote>%s</blockquote>
+			<p>Answer:</p>
+			<blockquote>%s</blockquote>
+		</body>
+		</html>
+	`, html.EscapeString(question.User.UserName), html.EscapeString(productName),
+		html.EscapeString(question.Body), html.EscapeString(answer.Body))
+
+	go func() {
+		if err := s.emailService.SendEmail(to, subject, body); err != nil {
+			// Log the error but don't fail the request
+			fmt.Printf("Failed to send answer notification to %s: %v\n", to, err)
+		}
+	}()
+}
+
+func (s *questionService) questionResponse(id uuid.UUID) (dto.QuestionResponse, error) {
+	question, err := s.questionRepo.FindQuestionByID(id)
+	if err != nil {
+		return dto.QuestionResponse{}, err
+	}
+	return dto.ToQuestionResponse(*question), nil
+}
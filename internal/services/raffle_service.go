@@ -0,0 +1,340 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/dto"
+	"github.com/akhilnasimk/SS_backend/internal/enums"
+	"github.com/akhilnasimk/SS_backend/internal/helpers"
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
+	"github.com/google/uuid"
+)
+
+const defaultReservationHours = 24
+
+type RaffleService interface {
+	GetOpenRaffles() ([]dto.RaffleResponse, error)
+	GetRaffle(raffleIDString string) (dto.RaffleResponse, error)
+	CreateRaffle(req dto.CreateRaffleRequest) (dto.RaffleResponse, error)
+
+	EnterRaffle(userIDString, raffleIDString string, req dto.RaffleEntryRequest) (dto.RaffleEntryResponse, error)
+	GetMyEntry(userIDString, raffleIDString string) (dto.RaffleEntryResponse, error)
+
+	DrawRaffle(adminIDString, raffleIDString string) (dto.RaffleDrawResponse, error)
+	GetRaffleEntries(raffleIDString string) (dto.RaffleDrawResponse, error)
+
+	PurchaseReservation(userIDString, raffleIDString string, req dto.CreateCartOrderDTO) (*models.Order, error)
+	StartReservationExpiry(interval time.Duration)
+}
+
+type raffleService struct {
+	raffleRepo   interfaces.RaffleRepository
+	productRepo  interfaces.ProductsRepository
+	emailService EmailService
+}
+
+func NewRaffleService(raffleRepo interfaces.RaffleRepository, productRepo interfaces.ProductsRepository, emailService EmailService) RaffleService {
+	return &raffleService{
+		raffleRepo:   raffleRepo,
+		productRepo:  productRepo,
+		emailService: emailService,
+	}
+}
+
+func (s *raffleService) GetOpenRaffles() ([]dto.RaffleResponse, error) {
+	raffles, err := s.raffleRepo.FindOpen(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]dto.RaffleResponse, 0, len(raffles))
+	for _, r := range raffles {
+		count, err := s.raffleRepo.CountEntries(r.ID)
+		if err != nil {
+			return nil, err
+		}
+		resp = append(resp, dto.ToRaffleResponse(r, count))
+	}
+	return resp, nil
+}
+
+func (s *raffleService) GetRaffle(raffleIDString string) (dto.RaffleResponse, error) {
+	raffleID, err := uuid.Parse(raffleIDString)
+	if err != nil {
+		return dto.RaffleResponse{}, errors.New("invalid raffle ID")
+	}
+
+	return s.raffleResponse(raffleID)
+}
+
+func (s *raffleService) CreateRaffle(req dto.CreateRaffleRequest) (dto.RaffleResponse, error) {
+	productID, err := uuid.Parse(req.ProductID)
+	if err != nil {
+		return dto.RaffleResponse{}, errors.New("invalid product ID")
+	}
+
+	if _, err := s.productRepo.FindById(productID); err != nil {
+		return dto.RaffleResponse{}, errors.New("product not found")
+	}
+
+	opensAt, err := parseScheduleTime(req.OpensAt)
+	if err != nil || opensAt == nil {
+		return dto.RaffleResponse{}, errors.New("invalid opens_at: must be an RFC 3339 timestamp")
+	}
+	closesAt, err := parseScheduleTime(req.ClosesAt)
+	if err != nil || closesAt == nil {
+		return dto.RaffleResponse{}, errors.New("invalid closes_at: must be an RFC 3339 timestamp")
+	}
+	if !closesAt.After(*opensAt) {
+		return dto.RaffleResponse{}, errors.New("invalid entry window: closes_at must be after opens_at")
+	}
+	if !closesAt.After(time.Now()) {
+		return dto.RaffleResponse{}, errors.New("invalid entry window: closes_at is in the past")
+	}
+
+	hours := req.ReservationHours
+	if hours <= 0 {
+		hours = defaultReservationHours
+	}
+
+	// commit to the draw seed now, before anyone has entered
+	seed, err := helpers.NewRaffleSeed()
+	if err != nil {
+		return dto.RaffleResponse{}, fmt.Errorf("failed to generate seed: %w", err)
+	}
+
+	raffle := models.Raffle{
+		ProductID:        productID,
+		OpensAt:          *opensAt,
+		ClosesAt:         *closesAt,
+		Status:           enums.RaffleOpen,
+		ReservationHours: hours,
+		Seed:             seed,
+		SeedHash:         helpers.RaffleSeedHash(seed),
+	}
+	if err := s.raffleRepo.Create(&raffle); err != nil {
+		return dto.RaffleResponse{}, err
+	}
+
+	return s.raffleResponse(raffle.ID)
+}
+
+func (s *raffleService) EnterRaffle(userIDString, raffleIDString string, req dto.RaffleEntryRequest) (dto.RaffleEntryResponse, error) {
+	userID, raffleID, err := parseRaffleIDs(userIDString, raffleIDString)
+	if err != nil {
+		return dto.RaffleEntryResponse{}, err
+	}
+
+	entry := models.RaffleEntry{
+		RaffleID: raffleID,
+		UserID:   userID,
+	}
+	if req.VariantID != "" {
+		variantID, err := uuid.Parse(req.VariantID)
+		if err != nil {
+			return dto.RaffleEntryResponse{}, errors.New("invalid variant ID")
+		}
+		entry.VariantID = &variantID
+	}
+
+	if err := s.raffleRepo.CreateEntry(&entry, time.Now()); err != nil {
+		return dto.RaffleEntryResponse{}, err
+	}
+
+	return s.GetMyEntry(userIDString, raffleIDString)
+}
+
+func (s *raffleService) GetMyEntry(userIDString, raffleIDString string) (dto.RaffleEntryResponse, error) {
+	userID, raffleID, err := parseRaffleIDs(userIDString, raffleIDString)
+	if err != nil {
+		return dto.RaffleEntryResponse{}, err
+	}
+
+	entry, err := s.raffleRepo.FindEntry(raffleID, userID)
+	if err != nil {
+		return dto.RaffleEntryResponse{}, err
+	}
+	if entry == nil {
+		return dto.RaffleEntryResponse{}, errors.New("raffle entry not found")
+	}
+
+	return dto.ToRaffleEntryResponse(*entry), nil
+}
+
+// DrawRaffle runs the draw with the seed committed at creation once the entry window has
+// closed, reveals the seed and emails the winners. The ranking can be re-derived from the
+// seed and the entry list, and the seed checked against the hash published beforehand.
+func (s *raffleService) DrawRaffle(adminIDString, raffleIDString string) (dto.RaffleDrawResponse, error) {
+	adminID, raffleID, err := parseRaffleIDs(adminIDString, raffleIDString)
+	if err != nil {
+		return dto.RaffleDrawResponse{}, err
+	}
+
+	if _, _, err := s.raffleRepo.Draw(raffleID, adminID, time.Now()); err != nil {
+		return dto.RaffleDrawResponse{}, err
+	}
+
+	resp, err := s.GetRaffleEntries(raffleIDString)
+	if err != nil {
+		return dto.RaffleDrawResponse{}, err
+	}
+
+	s.notifyWinners(raffleID)
+
+	return resp, nil
+}
+
+// GetRaffleEntries is the audit view: the raffle with its seed and every entry in draw order
+func (s *raffleService) GetRaffleEntries(raffleIDString string) (dto.RaffleDrawResponse, error) {
+	raffleID, err := uuid.Parse(raffleIDString)
+	if err != nil {
+		return dto.RaffleDrawResponse{}, errors.New("invalid raffle ID")
+	}
+
+	raffle, err := s.raffleRepo.FindByID(raffleID)
+	if err != nil {
+		return dto.RaffleDrawResponse{}, err
+	}
+
+	entries, err := s.raffleRepo.FindEntries(raffleID)
+	if err != nil {
+		return dto.RaffleDrawResponse{}, err
+	}
+
+	resp := dto.RaffleDrawResponse{
+		Raffle:  dto.ToRaffleResponse(*raffle, int64(len(entries))),
+		DrawnBy: raffle.DrawnBy,
+		Entries: make([]dto.RaffleAuditEntry, 0, len(entries)),
+	}
+	for _, e := range entries {
+		resp.Entries = append(resp.Entries, dto.ToRaffleAuditEntry(e))
+	}
+
+	return resp, nil
+}
+
+// PurchaseReservation places a one-unit order for a winning entry, without going through the cart
+func (s *raffleService) PurchaseReservation(userIDString, raffleIDString string, req dto.CreateCartOrderDTO) (*models.Order, error) {
+	userID, raffleID, err := parseRaffleIDs(userIDString, raffleIDString)
+	if err != nil {
+		return nil, err
+	}
+
+	order := &models.Order{
+		UserID:          userID,
+		Status:          "pending",
+		PaymentMethod:   req.PaymentMethod,
+		ShippingAddress: req.ShippingAddress,
+	}
+
+	if err := s.raffleRepo.PurchaseReservation(raffleID, userID, order, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// StartReservationExpiry returns lapsed winner reservations to stock on every tick, in the background
+func (s *raffleService) StartReservationExpiry(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			expired, err := s.raffleRepo.ExpireReservations(time.Now())
+			if err != nil {
+				log.Printf("raffle reservation expiry failed: %v", err)
+				continue
+			}
+			if expired > 0 {
+				log.Printf("released %d expired raffle reservations", expired)
+			}
+		}
+	}()
+}
+
+func (s *raffleService) raffleResponse(raffleID uuid.UUID) (dto.RaffleResponse, error) {
+	raffle, err := s.raffleRepo.FindByID(raffleID)
+	if err != nil {
+		return dto.RaffleResponse{}, err
+	}
+
+	count, err := s.raffleRepo.CountEntries(raffleID)
+	if err != nil {
+		return dto.RaffleResponse{}, err
+	}
+
+	return dto.ToRaffleResponse(*raffle, count), nil
+}
+
+func (s *raffleService) notifyWinners(raffleID uuid.UUID) {
+	raffle, err := s.raffleRepo.FindByID(raffleID)
+	if err != nil {
+		fmt.Printf("Failed to load raffle %s for winner emails: %v\n", raffleID, err)
+		return
+	}
+	entries, err := s.raffleRepo.FindEntries(raffleID)
+	if err != nil {
+		fmt.Printf("Failed to load raffle %s entries for winner emails: %v\n", raffleID, err)
+		return
+	}
+
+	productName := "the release"
+	if raffle.Product != nil {
+		productName = raffle.Product.Name
+	}
+
+	for _, e := range entries {
+		if e.Status != enums.EntryWon || e.User == nil || e.ReservationExpiresAt == nil {
+			continue
+		}
+
+		size := ""
+		if e.Variant != nil {
+			size = fmt.Sprintf(" (size %s)", html.EscapeString(e.Variant.Size))
+		}
+
+		to := e.User.Email
+		subject := fmt.Sprintf("You won the %s raffle", productName)
+		body := fmt.Sprintf(`
+			<html>
+			<body>
+				<p>Hello %s,</p>
+				<p>Your entry for <b>%s</b>%s was drawn as a winner.</p>
+				<p>One unit is reserved for you until <b>%s</b>. Complete your purchase from the raffle page before then; after that the reservation is released.</p>
+			</body>
+			</html>
+		`, html.EscapeString(e.User.UserName), html.EscapeString(productName), size,
+			e.ReservationExpiresAt.UTC().Format("02 Jan 2006 15:04 MST"))
+
+		go func() {
+			if err := s.emailService.SendEmail(to, subject, body); err != nil {
+				// Log the error but don't fail the draw
+				fmt.Printf("Failed to send raffle win email to %s: %v\n", to, err)
+			}
+		}()
+	}
+}
+
+func parseRaffleIDs(userIDString, raffleIDString string) (uuid.UUID, uuid.UUID, error) {
+	userID, err := uuid.Parse(userIDString)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errors.New("invalid user ID")
+	}
+
+	raffleID, err := uuid.Parse(raffleIDString)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errors.New("invalid raffle ID")
+	}
+
+	return userID, raffleID, nil
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/dto"
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
+	"github.com/google/uuid"
+)
+
+// maxRecentlyViewed bounds the history kept per user or guest
+const maxRecentlyViewed = 20
+
+type RecentlyViewedService interface {
+	RecordView(userIDString, guestIDString, productIDString string) error
+	GetForUser(userIDString string) ([]dto.RecentlyViewedResponse, error)
+	GetForGuest(guestIDString string) ([]dto.RecentlyViewedResponse, error)
+	MergeGuest(guestID, userID uuid.UUID) error
+}
+
+type recentlyViewedService struct {
+	recentRepo interfaces.RecentlyViewedRepository
+}
+
+func NewRecentlyViewedService(recentRepo interfaces.RecentlyViewedRepository) RecentlyViewedService {
+	return &recentlyViewedService{
+		recentRepo: recentRepo,
+	}
+}
+
+// RecordView stores a product view for the user, or for the guest when not logged in
+func (s *recentlyViewedService) RecordView(userIDString, guestIDString, productIDString string) error {
+	productID, err := uuid.Parse(productIDString)
+	if err != nil {
+		return fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	view := models.RecentlyViewed{
+		ProductID: productID,
+		ViewedAt:  time.Now(),
+	}
+
+	switch {
+	case userIDString != "":
+		userID, err := uuid.Parse(userIDString)
+		if err != nil {
+			return errors.New("invalid user ID")
+		}
+		view.UserID = &userID
+	case guestIDString != "":
+		guestID, err := uuid.Parse(guestIDString)
+		if err != nil {
+			return errors.New("invalid guest ID")
+		}
+		view.GuestID = &guestID
+	default:
+		return nil
+	}
+
+	return s.recentRepo.RecordView(&view, maxRecentlyViewed)
+}
+
+func (s *recentlyViewedService) GetForUser(userIDString string) ([]dto.RecentlyViewedResponse, error) {
+	userID, err := uuid.Parse(userIDString)
+	if err != nil {
+		return nil, errors.New("invalid user ID")
+	}
+
+	views, err := s.recentRepo.FindByUser(userID, maxRecentlyViewed)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToRecentlyViewedResponses(views), nil
+}
+
+func (s *recentlyViewedService) GetForGuest(guestIDString string) ([]dto.RecentlyViewedResponse, error) {
+	guestID, err := uuid.Parse(guestIDString)
+	if err != nil {
+		return nil, errors.New("invalid guest ID")
+	}
+
+	views, err := s.recentRepo.FindByGuest(guestID, maxRecentlyViewed)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToRecentlyViewedResponses(views), nil
+}
+
+// MergeGuest moves a guest's history into the account after login
+func (s *recentlyViewedService) MergeGuest(guestID, userID uuid.UUID) error {
+	return s.recentRepo.MergeGuest(guestID, userID, maxRecentlyViewed)
+}
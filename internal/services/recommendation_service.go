@@ -0,0 +1,96 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/dto"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultRecommendationLimit = 8
+	maxRecommendationLimit     = 20
+)
+
+type RecommendationService interface {
+	GetFrequentlyBoughtTogether(productIDString string, limit int) ([]dto.ProductResponse, error)
+	RefreshCoPurchases() (int64, error)
+	StartCoPurchaseRefresher(interval time.Duration)
+}
+
+type recommendationService struct {
+	recommendationRepo interfaces.RecommendationRepository
+	productRepo        interfaces.ProductsRepository
+}
+
+func NewRecommendationService(recommendationRepo interfaces.RecommendationRepository, productRepo interfaces.ProductsRepository) RecommendationService {
+	return &recommendationService{
+		recommendationRepo: recommendationRepo,
+		productRepo:        productRepo,
+	}
+}
+
+// GetFrequentlyBoughtTogether reads the precomputed pairs, so it stays cheap on product pages
+func (s *recommendationService) GetFrequentlyBoughtTogether(productIDString string, limit int) ([]dto.ProductResponse, error) {
+	productID, err := uuid.Parse(productIDString)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	if _, err := s.productRepo.FindById(productID); err != nil {
+		return nil, errors.New("product not found")
+	}
+
+	if limit <= 0 {
+		limit = defaultRecommendationLimit
+	}
+	if limit > maxRecommendationLimit {
+		limit = maxRecommendationLimit
+	}
+
+	products, err := s.recommendationRepo.FindCoPurchased(productID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]dto.ProductResponse, 0, len(products))
+	for _, p := range products {
+		resp = append(resp, dto.ToProductResponse(p))
+	}
+
+	return resp, nil
+}
+
+// RefreshCoPurchases rebuilds the pair table from order history, returns the number of pairs stored
+func (s *recommendationService) RefreshCoPurchases() (int64, error) {
+	pairs, err := s.recommendationRepo.RefreshCoPurchases()
+	if err != nil {
+		return 0, fmt.Errorf("failed to refresh recommendations: %w", err)
+	}
+	return pairs, nil
+}
+
+// StartCoPurchaseRefresher rebuilds the pairs right away and then on every tick, in the background
+func (s *recommendationService) StartCoPurchaseRefresher(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if pairs, err := s.RefreshCoPurchases(); err != nil {
+				log.Printf("co-purchase refresh failed: %v", err)
+			} else {
+				log.Printf("co-purchase refresh stored %d pairs", pairs)
+			}
+			<-ticker.C
+		}
+	}()
+}
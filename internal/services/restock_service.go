@@ -0,0 +1,165 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/dto"
+	"github.com/akhilnasimk/SS_backend/internal/enums"
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RestockService interface {
+	Subscribe(userIDString, productIDString string) (dto.RestockSubscriptionResponse, error)
+	Unsubscribe(userIDString, productIDString string) error
+	GetSubscription(userIDString, productIDString string) (dto.RestockSubscriptionResponse, error)
+	StartNotifier(interval time.Duration, batchSize int)
+}
+
+type restockService struct {
+	restockRepo  interfaces.RestockRepository
+	productRepo  interfaces.ProductsRepository
+	emailService EmailService
+}
+
+func NewRestockService(restockRepo interfaces.RestockRepository, productRepo interfaces.ProductsRepository, emailService EmailService) RestockService {
+	return &restockService{
+		restockRepo:  restockRepo,
+		productRepo:  productRepo,
+		emailService: emailService,
+	}
+}
+
+// Subscribe queues a back-in-stock email; only sold-out products take subscriptions
+func (s *restockService) Subscribe(userIDString, productIDString string) (dto.RestockSubscriptionResponse, error) {
+	userID, productID, err := parseRestockIDs(userIDString, productIDString)
+	if err != nil {
+		return dto.RestockSubscriptionResponse{}, err
+	}
+
+	product, err := s.productRepo.FindById(productID)
+	if err != nil || !product.IsActive {
+		return dto.RestockSubscriptionResponse{}, errors.New("product not found")
+	}
+
+	// same stock_count customers see: net of other shoppers' checkout holds
+	held, _, err := s.productRepo.HeldStock([]uuid.UUID{productID}, time.Now())
+	if err != nil {
+		return dto.RestockSubscriptionResponse{}, err
+	}
+	if product.StockCount-held[productID] > 0 {
+		return dto.RestockSubscriptionResponse{}, errors.New("product is in stock, restock alerts are only for sold-out products")
+	}
+
+	if err := s.restockRepo.Subscribe(&models.RestockSubscription{
+		ProductID: productID,
+		UserID:    userID,
+		Status:    enums.RestockPending,
+		CreatedAt: time.Now(),
+	}); err != nil {
+		return dto.RestockSubscriptionResponse{}, err
+	}
+
+	return s.GetSubscription(userIDString, productIDString)
+}
+
+// Unsubscribe drops a pending subscription
+func (s *restockService) Unsubscribe(userIDString, productIDString string) error {
+	userID, productID, err := parseRestockIDs(userIDString, productIDString)
+	if err != nil {
+		return err
+	}
+
+	removed, err := s.restockRepo.Unsubscribe(userID, productID)
+	if err != nil {
+		return err
+	}
+	if removed == 0 {
+		return errors.New("restock subscription not found")
+	}
+	return nil
+}
+
+// GetSubscription reports whether the caller is waiting for this product
+func (s *restockService) GetSubscription(userIDString, productIDString string) (dto.RestockSubscriptionResponse, error) {
+	userID, productID, err := parseRestockIDs(userIDString, productIDString)
+	if err != nil {
+		return dto.RestockSubscriptionResponse{}, err
+	}
+
+	sub, err := s.restockRepo.FindSubscription(userID, productID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return dto.RestockSubscriptionResponse{ProductID: productID}, nil
+	}
+	if err != nil {
+		return dto.RestockSubscriptionResponse{}, err
+	}
+
+	return dto.ToRestockSubscriptionResponse(*sub), nil
+}
+
+// StartNotifier emails back-in-stock subscribers in the background, at most batchSize per
+// tick, so a small restock doesn't email the whole queue at once. Stock is re-checked before
+// every batch: once the product sells out again the rest stay queued for the next restock.
+func (s *restockService) StartNotifier(interval time.Duration, batchSize int) {
+	if interval <= 0 || batchSize <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			notices, err := s.restockRepo.ClaimBatch(batchSize, time.Now())
+			if err != nil {
+				log.Printf("restock notification batch failed: %v", err)
+				continue
+			}
+			for _, n := range notices {
+				s.sendRestockEmail(n)
+			}
+			if len(notices) > 0 {
+				log.Printf("sent %d back-in-stock emails", len(notices))
+			}
+		}
+	}()
+}
+
+// sendRestockEmail runs on the notifier goroutine, so the batch is sent one email at a time
+func (s *restockService) sendRestockEmail(n interfaces.RestockNotice) {
+	subject := fmt.Sprintf("%s is back in stock", n.ProductName)
+	body := fmt.Sprintf(`
+		<html>
+		<body>
+			<p>Hello %s,</p>
+			<p><b>%s</b> is back in stock. Quantities are limited, so grab yours before it sells out again.</p>
+		</body>
+		</html>
+	`, html.EscapeString(n.UserName), html.EscapeString(n.ProductName))
+
+	if err := s.emailService.SendEmail(n.Email, subject, body); err != nil {
+		// The subscription stays fulfilled, one missed email is better than a resend loop
+		fmt.Printf("Failed to send restock email to %s: %v\n", n.Email, err)
+	}
+}
+
+func parseRestockIDs(userIDString, productIDString string) (uuid.UUID, uuid.UUID, error) {
+	userID, err := uuid.Parse(userIDString)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errors.New("invalid user ID")
+	}
+
+	productID, err := uuid.Parse(productIDString)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errors.New("invalid product ID")
+	}
+
+	return userID, productID, nil
+}
@@ -0,0 +1,243 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"strings"
+	"time"
+
+	constent "github.com/akhilnasimk/SS_backend/internal/const"
+	"github.com/akhilnasimk/SS_backend/internal/dto"
+	"github.com/akhilnasimk/SS_backend/internal/models"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
+	"github.com/akhilnasimk/SS_backend/utils/media"
+	"github.com/google/uuid"
+)
+
+const maxReviewPhotos = 4
+
+type ReviewService interface {
+	GetProductReviews(productIDString string, page, limit int, includeHidden bool) (dto.ReviewListResponse, error)
+	CreateReview(userIDString, productIDString string, req dto.ReviewRequest) (dto.ReviewResponse, error)
+	UpdateReview(userIDString, productIDString string, req dto.ReviewRequest) (dto.ReviewResponse, error)
+	SetReviewHidden(reviewIDString string, hidden bool, reason string) (dto.ReviewResponse, error)
+}
+
+type reviewService struct {
+	reviewRepo  interfaces.ReviewRepository
+	orderRepo   interfaces.OrderRepository
+	productRepo interfaces.ProductsRepository
+	media       media.MediaStore
+}
+
+func NewReviewService(reviewRepo interfaces.ReviewRepository, orderRepo interfaces.OrderRepository, productRepo interfaces.ProductsRepository, store media.MediaStore) ReviewService {
+	return &reviewService{
+		reviewRepo:  reviewRepo,
+		orderRepo:   orderRepo,
+		productRepo: productRepo,
+		media:       store,
+	}
+}
+
+func (s *reviewService) GetProductReviews(productIDString string, page, limit int, includeHidden bool) (dto.ReviewListResponse, error) {
+	productID, err := uuid.Parse(productIDString)
+	if err != nil {
+		return dto.ReviewListResponse{}, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	product, err := s.productRepo.FindById(productID)
+	if err != nil {
+		return dto.ReviewListResponse{}, errors.New("product not found")
+	}
+
+	limit = clampLimit(limit)
+	if page <= 0 {
+		page = 1
+	}
+
+	reviews, total, err := s.reviewRepo.FindByProduct(productID, limit, (page-1)*limit, includeHidden)
+	if err != nil {
+		return dto.ReviewListResponse{}, err
+	}
+
+	resp := dto.ReviewListResponse{
+		Reviews:       make([]dto.ReviewResponse, 0, len(reviews)),
+		Total:         total,
+		Page:          page,
+		Limit:         limit,
+		AverageRating: product.AverageRating,
+		ReviewCount:   product.ReviewCount,
+	}
+	for _, r := range reviews {
+		resp.Reviews = append(resp.Reviews, dto.ToReviewResponse(r))
+	}
+
+	return resp, nil
+}
+
+// CreateReview only accepts users with a delivered, non-cancelled purchase of the product
+func (s *reviewService) CreateReview(userIDString, productIDString string, req dto.ReviewRequest) (dto.ReviewResponse, error) {
+	userID, productID, err := parseReviewIDs(userIDString, productIDString)
+	if err != nil {
+		return dto.ReviewResponse{}, err
+	}
+
+	if _, err := s.productRepo.FindById(productID); err != nil {
+		return dto.ReviewResponse{}, errors.New("product not found")
+	}
+
+	verified, err := s.orderRepo.HasDeliveredPurchase(userID, productID)
+	if err != nil {
+		return dto.ReviewResponse{}, err
+	}
+	if !verified {
+		return dto.ReviewResponse{}, errors.New("only verified buyers can review this product: no delivered order found")
+	}
+
+	existing, err := s.reviewRepo.FindByUserAndProduct(userID, productID)
+	if err != nil {
+		return dto.ReviewResponse{}, err
+	}
+	if existing != nil {
+		return dto.ReviewResponse{}, errors.New("you already reviewed this product, update your review instead")
+	}
+
+	photos, err := s.uploadReviewPhotos(req.Photos, 0)
+	if err != nil {
+		return dto.ReviewResponse{}, err
+	}
+
+	review := models.Review{
+		ProductID: productID,
+		UserID:    userID,
+		Rating:    req.Rating,
+		Title:     strings.TrimSpace(req.Title),
+		Body:      strings.TrimSpace(req.Body),
+		Photos:    photos,
+	}
+
+	if err := s.reviewRepo.Create(&review); err != nil {
+		media.DeleteMultipleAsync(s.media, reviewPhotoURLs(photos))
+		return dto.ReviewResponse{}, fmt.Errorf("failed to save review: %w", err)
+	}
+
+	return s.reviewResponse(review.ID)
+}
+
+// UpdateReview edits the caller's own review; new photos are added to the existing ones
+func (s *reviewService) UpdateReview(userIDString, productIDString string, req dto.ReviewRequest) (dto.ReviewResponse, error) {
+	userID, productID, err := parseReviewIDs(userIDString, productIDString)
+	if err != nil {
+		return dto.ReviewResponse{}, err
+	}
+
+	existing, err := s.reviewRepo.FindByUserAndProduct(userID, productID)
+	if err != nil {
+		return dto.ReviewResponse{}, err
+	}
+	if existing == nil {
+		return dto.ReviewResponse{}, errors.New("review not found")
+	}
+
+	review, err := s.reviewRepo.FindByID(existing.ID)
+	if err != nil {
+		return dto.ReviewResponse{}, err
+	}
+
+	photos, err := s.uploadReviewPhotos(req.Photos, len(review.Photos))
+	if err != nil {
+		return dto.ReviewResponse{}, err
+	}
+
+	review.Rating = req.Rating
+	review.Title = strings.TrimSpace(req.Title)
+	review.Body = strings.TrimSpace(req.Body)
+	review.Photos = append(review.Photos, photos...)
+
+	if err := s.reviewRepo.Update(review); err != nil {
+		media.DeleteMultipleAsync(s.media, reviewPhotoURLs(photos))
+		return dto.ReviewResponse{}, fmt.Errorf("failed to update review: %w", err)
+	}
+
+	return s.reviewResponse(review.ID)
+}
+
+func (s *reviewService) SetReviewHidden(reviewIDString string, hidden bool, reason string) (dto.ReviewResponse, error) {
+	reviewID, err := uuid.Parse(reviewIDString)
+	if err != nil {
+		return dto.ReviewResponse{}, fmt.Errorf("invalid review ID: %w", err)
+	}
+
+	if _, err := s.reviewRepo.SetHidden(reviewID, hidden, strings.TrimSpace(reason)); err != nil {
+		return dto.ReviewResponse{}, err
+	}
+
+	return s.reviewResponse(reviewID)
+}
+
+// uploadReviewPhotos validates and stores photos; existing is how many the review already has
+func (s *reviewService) uploadReviewPhotos(files []*multipart.FileHeader, existing int) ([]models.ReviewPhoto, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	if existing+len(files) > maxReviewPhotos {
+		return nil, fmt.Errorf("invalid image: a review can have at most %d photos", maxReviewPhotos)
+	}
+
+	if err := validateImages(files); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	results, err := media.UploadMultiple(ctx, s.media, files, media.DefaultUploadOptions("reviews"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload photos: %w", err)
+	}
+
+	photos := make([]models.ReviewPhoto, 0, len(results))
+	for _, r := range results {
+		photos = append(photos, models.ReviewPhoto{
+			URL:          r.URL,
+			ThumbnailURL: r.Sizes[constent.ImageSizeThumbnail],
+			ZoomURL:      r.Sizes[constent.ImageSizeZoom],
+			CardURL:      r.Sizes[constent.ImageSizeCard],
+		})
+	}
+
+	return photos, nil
+}
+
+func (s *reviewService) reviewResponse(id uuid.UUID) (dto.ReviewResponse, error) {
+	review, err := s.reviewRepo.FindByID(id)
+	if err != nil {
+		return dto.ReviewResponse{}, err
+	}
+	return dto.ToReviewResponse(*review), nil
+}
+
+func parseReviewIDs(userIDString, productIDString string) (uuid.UUID, uuid.UUID, error) {
+	userID, err := uuid.Parse(userIDString)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errors.New("invalid user ID")
+	}
+
+	productID, err := uuid.Parse(productIDString)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	return userID, productID, nil
+}
+
+func reviewPhotoURLs(photos []models.ReviewPhoto) []string {
+	urls := make([]string, 0, len(photos))
+	for _, p := range photos {
+		urls = append(urls, p.AllURLs()...)
+	}
+	return urls
+}
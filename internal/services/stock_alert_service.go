@@ -0,0 +1,98 @@
+package services
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/dto"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
+	"github.com/google/uuid"
+)
+
+type StockAlertService interface {
+	CheckProducts(productIDs []uuid.UUID)
+	GetLowStock() ([]dto.LowStockProduct, error)
+}
+
+type stockAlertService struct {
+	alertRepo    interfaces.StockAlertRepository
+	userRepo     interfaces.UserRepository
+	emailService EmailService
+}
+
+func NewStockAlertService(alertRepo interfaces.StockAlertRepository, userRepo interfaces.UserRepository, emailService EmailService) StockAlertService {
+	return &stockAlertService{
+		alertRepo:    alertRepo,
+		userRepo:     userRepo,
+		emailService: emailService,
+	}
+}
+
+// CheckProducts is called after stock went down. Products that just crossed their threshold, and
+// sizes that just sold out, go to every admin in one digest email. Each is only reported again
+// after a restock clears its alert.
+func (s *stockAlertService) CheckProducts(productIDs []uuid.UUID) {
+	alerts, err := s.alertRepo.ClaimAlerts(productIDs, time.Now())
+	if err != nil {
+		fmt.Printf("Failed to check low stock alerts: %v\n", err)
+		return
+	}
+	if len(alerts) == 0 {
+		return
+	}
+
+	admins, err := s.userRepo.FindAdminEmails()
+	if err != nil {
+		fmt.Printf("Failed to load admin emails for low stock alert: %v\n", err)
+		return
+	}
+
+	var rows strings.Builder
+	for _, a := range alerts {
+		status := fmt.Sprintf("%d left (threshold %d)", a.StockCount, a.Threshold)
+		if a.StockCount <= 0 {
+			status = "sold out"
+		}
+		name := html.EscapeString(a.Name)
+		if a.Size != "" {
+			name = fmt.Sprintf("%s, size %s", name, html.EscapeString(a.Size))
+		}
+		fmt.Fprintf(&rows, "<li><b>%s</b>: %s</li>", name, status)
+	}
+
+	subject := fmt.Sprintf("Low stock alert: %d item(s) need restocking", len(alerts))
+	body := fmt.Sprintf(`
+		<html>
+		<body>
+			<p>The following products are running low or have sold out:</p>
+			<ul>%s</ul>
+			<p>You will not be alerted again for these until they are restocked.</p>
+		</body>
+		</html>
+	`, rows.String())
+
+	for _, to := range admins {
+		go func() {
+			if err := s.emailService.SendEmail(to, subject, body); err != nil {
+				// Log the error, the order already went through
+				fmt.Printf("Failed to send low stock alert to %s: %v\n", to, err)
+			}
+		}()
+	}
+}
+
+// GetLowStock lists every product currently at or below its threshold or with a sold-out size
+func (s *stockAlertService) GetLowStock() ([]dto.LowStockProduct, error) {
+	products, err := s.alertRepo.FindLowStock()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]dto.LowStockProduct, 0, len(products))
+	for _, p := range products {
+		resp = append(resp, dto.ToLowStockProduct(p))
+	}
+	return resp, nil
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/akhilnasimk/SS_backend/internal/dto"
+	"github.com/akhilnasimk/SS_backend/internal/enums"
+	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
+	"github.com/google/uuid"
+)
+
+type StockService interface {
+	GetStockHistory(productIDString string, page, limit int) (dto.StockHistoryResponse, error)
+	AdjustStock(adminIDString, productIDString string, req dto.StockAdjustmentRequest) (dto.StockMovementResponse, error)
+	ReconcileStock(all bool) (dto.StockReconciliationResponse, error)
+}
+
+type stockService struct {
+	stockRepo   interfaces.StockMovementRepository
+	productRepo interfaces.ProductsRepository
+}
+
+func NewStockService(stockRepo interfaces.StockMovementRepository, productRepo interfaces.ProductsRepository) StockService {
+	return &stockService{
+		stockRepo:   stockRepo,
+		productRepo: productRepo,
+	}
+}
+
+// GetStockHistory pages a product's stock ledger, newest movement first
+func (s *stockService) GetStockHistory(productIDString string, page, limit int) (dto.StockHistoryResponse, error) {
+	productID, err := uuid.Parse(productIDString)
+	if err != nil {
+		return dto.StockHistoryResponse{}, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	product, err := s.productRepo.FindById(productID)
+	if err != nil {
+		return dto.StockHistoryResponse{}, errors.New("product not found")
+	}
+
+	limit = clampLimit(limit)
+	if page <= 0 {
+		page = 1
+	}
+
+	movements, total, err := s.stockRepo.FindByProduct(productID, limit, (page-1)*limit)
+	if err != nil {
+		return dto.StockHistoryResponse{}, err
+	}
+
+	resp := dto.StockHistoryResponse{
+		ProductID:  product.ID,
+		StockCount: product.StockCount,
+		Movements:  make([]dto.StockMovementResponse, 0, len(movements)),
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+	}
+	for _, m := range movements {
+		resp.Movements = append(resp.Movements, dto.ToStockMovementResponse(m))
+	}
+
+	return resp, nil
+}
+
+// AdjustStock applies a manual change; sales and cancellations only come from orders
+func (s *stockService) AdjustStock(adminIDString, productIDString string, req dto.StockAdjustmentRequest) (dto.StockMovementResponse, error) {
+	adminID, err := uuid.Parse(adminIDString)
+	if err != nil {
+		return dto.StockMovementResponse{}, errors.New("invalid user ID")
+	}
+	productID, err := uuid.Parse(productIDString)
+	if err != nil {
+		return dto.StockMovementResponse{}, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	reason := enums.StockMovementReason(req.Reason)
+	if reason != enums.MovementAdminAdjustment && reason != enums.MovementReturn {
+		return dto.StockMovementResponse{}, fmt.Errorf("invalid reason %q: use %s or %s", req.Reason, enums.MovementAdminAdjustment, enums.MovementReturn)
+	}
+	if reason == enums.MovementReturn && req.Delta < 0 {
+		return dto.StockMovementResponse{}, errors.New("invalid adjustment: a return adds stock, delta must be positive")
+	}
+
+	var variantID *uuid.UUID
+	if req.VariantID != "" {
+		id, err := uuid.Parse(req.VariantID)
+		if err != nil {
+			return dto.StockMovementResponse{}, errors.New("invalid variant ID")
+		}
+		variantID = &id
+	}
+
+	movement, err := s.stockRepo.Adjust(productID, variantID, req.Delta, reason, strings.TrimSpace(req.Note), adminID)
+	if err != nil {
+		return dto.StockMovementResponse{}, err
+	}
+
+	return dto.ToStockMovementResponse(*movement), nil
+}
+
+// ReconcileStock compares every product's stock_count with its ledger total; only
+// mismatches are listed unless all is set
+func (s *stockService) ReconcileStock(all bool) (dto.StockReconciliationResponse, error) {
+	rows, err := s.stockRepo.Reconcile()
+	if err != nil {
+		return dto.StockReconciliationResponse{}, err
+	}
+
+	resp := dto.StockReconciliationResponse{
+		Checked:  len(rows),
+		Products: make([]dto.StockReconciliationRow, 0),
+	}
+	for _, r := range rows {
+		diff := r.StockCount - r.LedgerStock
+		if diff != 0 {
+			resp.Mismatched++
+		}
+		if diff == 0 && !all {
+			continue
+		}
+
+		resp.Products = append(resp.Products, dto.StockReconciliationRow{
+			ProductID:   r.ProductID,
+			ProductName: r.ProductName,
+			StockCount:  r.StockCount,
+			LedgerStock: r.LedgerStock,
+			Difference:  diff,
+			Movements:   r.Movements,
+		})
+	}
+
+	return resp, nil
+}
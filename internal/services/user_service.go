@@ -15,6 +15,7 @@ type UserService interface {
 	GetProfile(id uuid.UUID) (dto.UserProfileResponse, error)
 	UpdateProfile(userID uuid.UUID, profile *dto.UpdateProfileRequest) error
 	GetAllUserData(limit, offset int) ([]dto.AdminUserResponse, error)
+	GetUsersByCursor(limit int, cursor string) ([]dto.AdminUserResponse, string, error)
 	GetUserById(ctx context.Context, stringID string) (dto.AdminUserResponse, error)
 	AdminUserUpdate(ctx context.Context, req dto.PatchUserAdminReq, idstring string) error
 	ToggleUserStatus(idstring string) error
@@ -54,6 +55,8 @@ func (S *userService) GetProfile(id uuid.UUID) (dto.UserProfileResponse, error)
 		Address:   user.Address,
 		IsBlocked: user.IsBlocked,
 		UserRole:  user.UserRole,
+
+		PriceDropAlerts: user.PriceDropAlerts,
 	}
 
 	return profile, nil
@@ -93,6 +96,10 @@ func (s *userService) UpdateProfile(userID uuid.UUID, profile *dto.UpdateProfile
 		updates["address"] = *profile.Address
 	}
 
+	if profile.PriceDropAlerts != nil {
+		updates["price_drop_alerts"] = *profile.PriceDropAlerts
+	}
+
 	// No fields to update
 	if len(updates) == 0 {
 		return fmt.Errorf("no valid fields provided to update")
@@ -127,6 +134,45 @@ func (s *userService) GetAllUserData(limit, offset int) ([]dto.AdminUserResponse
 	return userResponses, nil
 }
 
+// GetUsersByCursor is the keyset-paginated admin user list (newest first)
+func (s *userService) GetUsersByCursor(limit int, cursor string) ([]dto.AdminUserResponse, string, error) {
+	after, err := parseKeyset(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	limit = clampLimit(limit)
+
+	users, err := s.userRepo.GetUsersByCursor(limit+1, after)
+	if err != nil {
+		return nil, "", err
+	}
+
+	users, hasMore := helpers.TrimPage(users, limit)
+
+	userResponses := make([]dto.AdminUserResponse, 0, len(users))
+	for _, u := range users {
+		userResponses = append(userResponses, dto.AdminUserResponse{
+			ID:        u.ID,
+			UserName:  u.UserName,
+			Email:     u.Email,
+			Image:     u.Image,
+			IsAdmin:   u.IsAdmin,
+			IsBlocked: u.IsBlocked,
+			CreatedAt: u.CreatedAt,
+			UserRole:  u.UserRole,
+		})
+	}
+
+	next := ""
+	if len(users) > 0 {
+		last := users[len(users)-1]
+		next = nextCursor(hasMore, last.CreatedAt, last.ID)
+	}
+
+	return userResponses, next, nil
+}
+
 func (s *userService) GetUserById(ctx context.Context, stringID string) (dto.AdminUserResponse, error) {
 	// Convert string to UUID
 	id := helpers.StringToUUID(stringID)
@@ -2,11 +2,14 @@ package services
 
 import (
 	"errors"
+	"fmt"
+	"html"
 
 	"github.com/akhilnasimk/SS_backend/internal/dto"
 	"github.com/akhilnasimk/SS_backend/internal/helpers"
 	"github.com/akhilnasimk/SS_backend/internal/models"
 	"github.com/akhilnasimk/SS_backend/internal/repositories/interfaces"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -15,17 +18,20 @@ type WishlistService interface {
 	DeleteWishlistItem(userID, productID string) error
 	ToggleWishlist(userID, productID string) (string, *models.Wishlist, error)
 	CheckWishlistStatus(userIDStr, productIDStr string) (*dto.WishlistStatusResponse, error)
+	NotifyPriceDrop(productID uuid.UUID, productName string, oldPrice, newPrice int64)
 }
 
 type wishlistService struct {
 	wishlistRepo interfaces.WishlistRepository
 	productRepo  interfaces.ProductsRepository
+	emailService EmailService
 }
 
-func NewWishlistService(wishlistRepo interfaces.WishlistRepository, productRepo interfaces.ProductsRepository) WishlistService {
+func NewWishlistService(wishlistRepo interfaces.WishlistRepository, productRepo interfaces.ProductsRepository, emailService EmailService) WishlistService {
 	return &wishlistService{
 		wishlistRepo: wishlistRepo,
 		productRepo:  productRepo,
+		emailService: emailService,
 	}
 }
 
@@ -89,7 +95,7 @@ func (s *wishlistService) ToggleWishlist(userID, productID string) (string, *mod
 	}
 
 	// Toggle wishlist
-	action, item, err := s.wishlistRepo.ToggleWishlist(uid, pid)
+	action, item, err := s.wishlistRepo.ToggleWishlist(uid, pid, product.Price)
 	if err != nil {
 		return "", nil, err
 	}
@@ -125,3 +131,46 @@ func (s *wishlistService) CheckWishlistStatus(userIDStr, productIDStr string) (*
 		Message:    "Product is in wishlist",
 	}, nil
 }
+
+// NotifyPriceDrop emails everyone who wishlisted the product (unless they opted out) that
+// its price went down, once per price. Emails go out one after another, so callers run it
+// in the background.
+func (s *wishlistService) NotifyPriceDrop(productID uuid.UUID, productName string, oldPrice, newPrice int64) {
+	if newPrice >= oldPrice {
+		return
+	}
+
+	recipients, err := s.wishlistRepo.ClaimPriceDropRecipients(productID, newPrice)
+	if err != nil {
+		fmt.Printf("Failed to load wishlist price drop recipients for %s: %v\n", productID, err)
+		return
+	}
+	if len(recipients) == 0 {
+		return
+	}
+
+	subject := fmt.Sprintf("Price drop on %s", productName)
+
+	for _, r := range recipients {
+		since := ""
+		if r.PriceAtAdd > newPrice && r.PriceAtAdd != oldPrice {
+			since = fmt.Sprintf("<p>That is %d less than when you added it to your wishlist (%d).</p>", r.PriceAtAdd-newPrice, r.PriceAtAdd)
+		}
+
+		body := fmt.Sprintf(`
+			<html>
+			<body>
+				<p>Hello %s,</p>
+				<p><b>%s</b> from your wishlist just dropped in price: <s>%d</s> <b>%d</b>.</p>
+				%s
+				<p>You can turn off price-drop emails in your profile settings.</p>
+			</body>
+			</html>
+		`, html.EscapeString(r.UserName), html.EscapeString(productName), oldPrice, newPrice, since)
+
+		if err := s.emailService.SendEmail(r.Email, subject, body); err != nil {
+			// Log the error, the price update already went through
+			fmt.Printf("Failed to send price drop email to %s: %v\n", r.Email, err)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	_ "image/png" // register decoders for image.Decode
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// Rules are the server side checks an upload must pass
+type Rules struct {
+	MaxBytes  int64
+	MinWidth  int
+	MinHeight int
+	MaxWidth  int // 0 = no limit
+	MaxHeight int // 0 = no limit
+}
+
+// allowedTypes are checked against the sniffed content, never the client's Content-Type
+var allowedTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+}
+
+const jpegQuality = 85
+
+// Validate checks size, sniffed MIME type and resolution, reading only the image header
+func Validate(file *multipart.FileHeader, rules Rules) error {
+	if rules.MaxBytes > 0 && file.Size > rules.MaxBytes {
+		return fmt.Errorf("invalid image %s: larger than %d KB", file.Filename, rules.MaxBytes/1024)
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("invalid image %s: %w", file.Filename, err)
+	}
+	defer f.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("invalid image %s: empty file", file.Filename)
+	}
+
+	contentType := http.DetectContentType(head[:n])
+	if !allowedTypes[contentType] {
+		return fmt.Errorf("invalid image %s: unsupported type %s (allowed: jpeg, png, webp)", file.Filename, contentType)
+	}
+
+	cfg, _, err := image.DecodeConfig(io.MultiReader(bytes.NewReader(head[:n]), f))
+	if err != nil {
+		return fmt.Errorf("invalid image %s: cannot read image: %v", file.Filename, err)
+	}
+
+	if cfg.Width < rules.MinWidth || cfg.Height < rules.MinHeight {
+		return fmt.Errorf("invalid image %s: %dx%d is below the minimum %dx%d", file.Filename, cfg.Width, cfg.Height, rules.MinWidth, rules.MinHeight)
+	}
+
+	// the header is all that's read so far, refuse before a huge bitmap gets decoded
+	if (rules.MaxWidth > 0 && cfg.Width > rules.MaxWidth) || (rules.MaxHeight > 0 && cfg.Height > rules.MaxHeight) {
+		return fmt.Errorf("invalid image %s: %dx%d is above the maximum %dx%d", file.Filename, cfg.Width, cfg.Height, rules.MaxWidth, rules.MaxHeight)
+	}
+
+	return nil
+}
+
+// Resize scales src down to width, keeping the aspect ratio. Images already narrower are not upscaled.
+func Resize(src image.Image, width int) image.Image {
+	b := src.Bounds()
+	if b.Dx() <= width {
+		width = b.Dx()
+	}
+	height := b.Dy() * width / b.Dx()
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	// flatten transparency onto white, derivatives are JPEG
+	draw.Draw(dst, dst.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Over, nil)
+	return dst
+}
+
+// EncodeJPEG encodes a derivative
+func EncodeJPEG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,97 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	rules := Rules{MaxBytes: 1 << 20, MinWidth: 500, MinHeight: 500, MaxWidth: 4000, MaxHeight: 4000}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		rules   Rules
+		wantErr string
+	}{
+		{name: "within the rules", data: pngBytes(t, 800, 600), rules: rules},
+		{name: "too small", data: pngBytes(t, 400, 600), rules: rules, wantErr: "below the minimum"},
+		{name: "too large", data: pngBytes(t, 4200, 600), rules: rules, wantErr: "above the maximum"},
+		{name: "no maximum", data: pngBytes(t, 4200, 600), rules: Rules{MinWidth: 500, MinHeight: 500}},
+		{name: "file too big", data: pngBytes(t, 800, 600), rules: Rules{MaxBytes: 10}, wantErr: "larger than"},
+		{name: "not an image", data: []byte("GIF89a is not allowed here"), rules: rules, wantErr: "unsupported type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(fileHeader(t, "shoe.png", tt.data), tt.rules)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResize(t *testing.T) {
+	tests := []struct {
+		name                  string
+		width, height, target int
+		wantWidth, wantHeight int
+	}{
+		{name: "scales down keeping the ratio", width: 1200, height: 900, target: 600, wantWidth: 600, wantHeight: 450},
+		{name: "never upscales", width: 300, height: 200, target: 600, wantWidth: 300, wantHeight: 200},
+		{name: "keeps at least one row", width: 2000, height: 1, target: 100, wantWidth: 100, wantHeight: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Resize(image.NewRGBA(image.Rect(0, 0, tt.width, tt.height)), tt.target).Bounds()
+			if got.Dx() != tt.wantWidth || got.Dy() != tt.wantHeight {
+				t.Fatalf("Resize(%dx%d, %d) = %dx%d, want %dx%d",
+					tt.width, tt.height, tt.target, got.Dx(), got.Dy(), tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func pngBytes(t *testing.T, width, height int) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// fileHeader builds a multipart file header the way gin hands it to the handlers
+func fileHeader(t *testing.T, name string, data []byte) *multipart.FileHeader {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("image", name)
+	if err != nil {
+		t.Fatalf("create form file: %v", err)
+	}
+	part.Write(data)
+	writer.Close()
+
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(32 << 20)
+	if err != nil {
+		t.Fatalf("read form: %v", err)
+	}
+	t.Cleanup(func() { form.RemoveAll() })
+
+	return form.File["image"][0]
+}
@@ -0,0 +1,66 @@
+package media
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/akhilnasimk/SS_backend/internal/helpers"
+	"github.com/cloudinary/cloudinary-go/v2"
+	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
+)
+
+// cloudinaryStore keeps images on Cloudinary (production backend)
+type cloudinaryStore struct {
+	cld *cloudinary.Cloudinary
+}
+
+func NewCloudinaryStore(cloudName, apiKey, apiSecret string) (MediaStore, error) {
+	cld, err := cloudinary.NewFromParams(cloudName, apiKey, apiSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize cloudinary: %w", err)
+	}
+
+	return &cloudinaryStore{cld: cld}, nil
+}
+
+func (s *cloudinaryStore) Upload(ctx context.Context, file io.Reader, folder, filename string) (string, error) {
+	resp, err := s.cld.Upload.Upload(ctx, file, uploader.UploadParams{
+		Folder: folder,
+	})
+	if err != nil {
+		return "", fmt.Errorf("cloudinary upload failed: %w", err)
+	}
+
+	return resp.SecureURL, nil
+}
+
+func (s *cloudinaryStore) Delete(ctx context.Context, url string) error {
+	if url == "" {
+		return nil
+	}
+
+	publicID := helpers.ExtractPublicID(url)
+	if publicID == "" {
+		return fmt.Errorf("invalid cloudinary URL")
+	}
+
+	_, err := s.cld.Upload.Destroy(ctx, uploader.DestroyParams{
+		PublicID: publicID,
+	})
+	return err
+}
+
+// URL takes a Cloudinary public ID
+func (s *cloudinaryStore) URL(key string) string {
+	img, err := s.cld.Image(key)
+	if err != nil {
+		return ""
+	}
+
+	url, err := img.String()
+	if err != nil {
+		return ""
+	}
+	return url
+}
@@ -0,0 +1,108 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// localStore writes images to a directory that the server exposes as a static route
+type localStore struct {
+	dir     string
+	baseURL string
+}
+
+// NewLocalStore stores files under dir and serves them from baseURL (e.g. "/media" or "http://localhost:8080/media")
+func NewLocalStore(dir, baseURL string) (MediaStore, error) {
+	if dir == "" {
+		return nil, errors.New("media local dir is required for the local backend")
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create media dir: %w", err)
+	}
+
+	return &localStore{dir: dir, baseURL: strings.TrimSuffix(baseURL, "/")}, nil
+}
+
+func (s *localStore) Upload(ctx context.Context, file io.Reader, folder, filename string) (string, error) {
+	key := newObjectKey(folder, filename)
+	target := filepath.Join(s.dir, filepath.FromSlash(key))
+
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create media folder: %w", err)
+	}
+
+	out, err := os.Create(target)
+	if err != nil {
+		return "", fmt.Errorf("failed to create media file: %w", err)
+	}
+
+	if _, err := io.Copy(out, file); err != nil {
+		out.Close()
+		os.Remove(target)
+		return "", fmt.Errorf("failed to write media file: %w", err)
+	}
+
+	if err := out.Close(); err != nil {
+		os.Remove(target)
+		return "", fmt.Errorf("failed to write media file: %w", err)
+	}
+
+	return s.URL(key), nil
+}
+
+func (s *localStore) Delete(ctx context.Context, url string) error {
+	key, ok := keyFromURL(s.baseURL, url)
+	if !ok {
+		return nil // not one of ours
+	}
+
+	err := os.Remove(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *localStore) URL(key string) string {
+	return s.baseURL + "/" + key
+}
+
+// newObjectKey names a stored file: folder/<uuid><ext>, the client filename is never used as a path
+func newObjectKey(folder, filename string) string {
+	ext := strings.ToLower(filepath.Ext(filename))
+	for _, r := range strings.TrimPrefix(ext, ".") {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			ext = ""
+			break
+		}
+	}
+
+	folder = strings.Trim(path.Clean("/"+folder), "/")
+	if folder == "" {
+		return uuid.NewString() + ext
+	}
+	return folder + "/" + uuid.NewString() + ext
+}
+
+// keyFromURL strips the base URL and rejects keys that would escape the store
+func keyFromURL(baseURL, url string) (string, bool) {
+	if !strings.HasPrefix(url, baseURL+"/") {
+		return "", false
+	}
+
+	key := strings.TrimPrefix(url, baseURL+"/")
+	clean := path.Clean("/" + key)
+	if clean != "/"+key || key == "" {
+		return "", false
+	}
+	return key, true
+}
@@ -0,0 +1,110 @@
+package media
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalStoreUploadAndDelete(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewLocalStore(dir, "/media/")
+	if err != nil {
+		t.Fatalf("NewLocalStore() error = %v", err)
+	}
+
+	url, err := store.Upload(context.Background(), strings.NewReader("jpeg bytes"), "products/card", "../../etc/passwd.JPG")
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	if !strings.HasPrefix(url, "/media/products/card/") || !strings.HasSuffix(url, ".jpg") {
+		t.Fatalf("Upload() url = %q, want /media/products/card/<id>.jpg", url)
+	}
+
+	target := filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(url, "/media/")))
+	data, err := os.ReadFile(target)
+	if err != nil || string(data) != "jpeg bytes" {
+		t.Fatalf("stored file = %q, %v", data, err)
+	}
+
+	if err := store.Delete(context.Background(), url); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := os.Stat(target); !os.IsNotExist(err) {
+		t.Fatalf("file still exists after Delete(): %v", err)
+	}
+	// deleting twice is not an error
+	if err := store.Delete(context.Background(), url); err != nil {
+		t.Fatalf("second Delete() error = %v", err)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	store := NewMemoryStore()
+
+	url, err := store.Upload(context.Background(), strings.NewReader("png bytes"), "reviews", "photo.png")
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	if data, ok := store.Get(url); !ok || string(data) != "png bytes" {
+		t.Fatalf("Get(%q) = %q, %v", url, data, ok)
+	}
+
+	store.Delete(context.Background(), url)
+	if store.Len() != 0 {
+		t.Fatalf("Len() = %d after Delete(), want 0", store.Len())
+	}
+}
+
+func TestNewObjectKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		folder     string
+		filename   string
+		wantPrefix string
+		wantExt    string
+	}{
+		{name: "folder and extension", folder: "products", filename: "shoe.PNG", wantPrefix: "products/", wantExt: ".png"},
+		{name: "no folder", folder: "", filename: "shoe.webp", wantPrefix: "", wantExt: ".webp"},
+		{name: "folder cannot escape", folder: "../../etc", filename: "shoe.jpg", wantPrefix: "etc/", wantExt: ".jpg"},
+		{name: "odd extension is dropped", folder: "products", filename: "shoe.j$g", wantPrefix: "products/", wantExt: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := newObjectKey(tt.folder, tt.filename)
+			if !strings.HasPrefix(key, tt.wantPrefix) {
+				t.Fatalf("newObjectKey() = %q, want prefix %q", key, tt.wantPrefix)
+			}
+			name := strings.TrimPrefix(key, tt.wantPrefix)
+			if strings.Contains(name, "/") || filepath.Ext(name) != tt.wantExt {
+				t.Fatalf("newObjectKey() = %q, want <id>%s", key, tt.wantExt)
+			}
+		})
+	}
+}
+
+func TestKeyFromURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		url    string
+		want   string
+		wantOK bool
+	}{
+		{name: "own url", url: "/media/products/a.jpg", want: "products/a.jpg", wantOK: true},
+		{name: "other store", url: "https://res.cloudinary.com/x/a.jpg", wantOK: false},
+		{name: "path traversal", url: "/media/../secrets", wantOK: false},
+		{name: "base url only", url: "/media/", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := keyFromURL("/media", tt.url)
+			if ok != tt.wantOK || got != tt.want {
+				t.Fatalf("keyFromURL(%q) = %q, %v, want %q, %v", tt.url, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
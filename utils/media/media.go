@@ -0,0 +1,193 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"io"
+	"log"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/akhilnasimk/SS_backend/internal/config"
+	constent "github.com/akhilnasimk/SS_backend/internal/const"
+	"github.com/akhilnasimk/SS_backend/utils/imaging"
+)
+
+// Supported values for config.Config.MediaBackend
+const (
+	BackendCloudinary = "cloudinary"
+	BackendLocal      = "local"
+	BackendMemory     = "memory"
+)
+
+// MediaStore is where product images live. Everything outside this package only
+// keeps the public URL returned by Upload.
+type MediaStore interface {
+	// Upload stores the file under folder and returns its public URL
+	Upload(ctx context.Context, file io.Reader, folder, filename string) (string, error)
+	// Delete removes a file by the URL Upload returned; unknown URLs are not an error
+	Delete(ctx context.Context, url string) error
+	// URL turns a storage key ("products/abc.jpg") into its public URL
+	URL(key string) string
+}
+
+// New builds the store selected by MEDIA_BACKEND
+func New(cfg *config.Config) (MediaStore, error) {
+	switch cfg.MediaBackend {
+	case BackendCloudinary, "":
+		return NewCloudinaryStore(cfg.CLOUDINARY_CLOUD_NAME, cfg.CLOUDINARY_API_KEY, cfg.CLOUDINARY_API_SECRET)
+	case BackendLocal:
+		return NewLocalStore(cfg.MediaLocalDir, cfg.MediaBaseURL)
+	case BackendMemory:
+		return NewMemoryStore(), nil
+	default:
+		return nil, fmt.Errorf("unknown media backend %q", cfg.MediaBackend)
+	}
+}
+
+// UploadResult represents the result of a single upload
+type UploadResult struct {
+	URL     string
+	AltText string
+	// derivative URLs by size (constent.ImageSize*)
+	Sizes map[string]string
+	Error error
+}
+
+// URLs lists the original and its derivatives
+func (r UploadResult) URLs() []string {
+	urls := make([]string, 0, len(r.Sizes)+1)
+	if r.URL != "" {
+		urls = append(urls, r.URL)
+	}
+	for _, u := range r.Sizes {
+		if u != "" {
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
+
+// UploadOptions defines upload configuration
+type UploadOptions struct {
+	Folder  string
+	Timeout time.Duration
+}
+
+// DefaultUploadOptions returns default upload settings
+func DefaultUploadOptions(folder string) UploadOptions {
+	return UploadOptions{
+		Folder:  folder,
+		Timeout: 30 * time.Second,
+	}
+}
+
+// UploadMultiple uploads multiple images in parallel, each with its resized derivatives;
+// if any fails the successful ones are removed again. Files should be checked with imaging.Validate first.
+// Results come back in the order of files, whichever upload finishes first.
+func UploadMultiple(ctx context.Context, store MediaStore, files []*multipart.FileHeader, opts UploadOptions) ([]UploadResult, error) {
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files provided")
+	}
+
+	type indexedResult struct {
+		index  int
+		result UploadResult
+	}
+	resultsChan := make(chan indexedResult, len(files))
+
+	// Upload files in parallel
+	for i, file := range files {
+		go func(i int, f *multipart.FileHeader) {
+			resultsChan <- indexedResult{index: i, result: uploadImage(ctx, store, f, opts)}
+		}(i, file)
+	}
+
+	// Collect results, each at its file's index
+	results := make([]UploadResult, len(files))
+	var uploadedURLs []string
+	var uploadErrors []error
+
+	for i := 0; i < len(files); i++ {
+		select {
+		case r := <-resultsChan:
+			results[r.index] = r.result
+			if r.result.Error != nil {
+				uploadErrors = append(uploadErrors, r.result.Error)
+			} else {
+				uploadedURLs = append(uploadedURLs, r.result.URLs()...)
+			}
+		case <-ctx.Done():
+			// Timeout occurred - cleanup uploaded files
+			DeleteMultipleAsync(store, uploadedURLs)
+			return nil, fmt.Errorf("upload timeout exceeded")
+		}
+	}
+
+	// If any upload failed, cleanup successful uploads
+	if len(uploadErrors) > 0 {
+		DeleteMultipleAsync(store, uploadedURLs)
+		return nil, fmt.Errorf("failed to upload %d images: %v", len(uploadErrors), uploadErrors[0])
+	}
+
+	return results, nil
+}
+
+// uploadImage stores the original and one JPEG per derivative size under opts.Folder/<size>
+func uploadImage(ctx context.Context, store MediaStore, f *multipart.FileHeader, opts UploadOptions) UploadResult {
+	fileReader, err := f.Open()
+	if err != nil {
+		return UploadResult{Error: err}
+	}
+	defer fileReader.Close()
+
+	data, err := io.ReadAll(fileReader)
+	if err != nil {
+		return UploadResult{Error: err}
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return UploadResult{Error: fmt.Errorf("invalid image %s: %w", f.Filename, err)}
+	}
+
+	result := UploadResult{AltText: f.Filename, Sizes: make(map[string]string, len(constent.ImageSizeWidths))}
+
+	result.URL, err = store.Upload(ctx, bytes.NewReader(data), opts.Folder, f.Filename)
+	if err != nil {
+		return UploadResult{Error: err}
+	}
+
+	base := strings.TrimSuffix(f.Filename, filepath.Ext(f.Filename))
+	for size, width := range constent.ImageSizeWidths {
+		resized, err := imaging.EncodeJPEG(imaging.Resize(src, width))
+		if err == nil {
+			result.Sizes[size], err = store.Upload(ctx, bytes.NewReader(resized), opts.Folder+"/"+size, base+"_"+size+".jpg")
+		}
+		if err != nil {
+			DeleteMultipleAsync(store, result.URLs())
+			return UploadResult{Error: fmt.Errorf("failed to store %s image: %w", size, err)}
+		}
+	}
+
+	return result
+}
+
+// DeleteMultipleAsync removes multiple files asynchronously (fire and forget)
+func DeleteMultipleAsync(store MediaStore, urls []string) {
+	if len(urls) == 0 {
+		return
+	}
+
+	go func() {
+		for _, url := range urls {
+			if err := store.Delete(context.Background(), url); err != nil {
+				log.Printf("Failed to delete media %s: %v", url, err)
+			}
+		}
+	}()
+}
@@ -0,0 +1,72 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"strings"
+	"testing"
+	"time"
+)
+
+// slowFirstStore delays every upload of the first file, so it finishes after the others
+type slowFirstStore struct {
+	*MemoryStore
+}
+
+func (s slowFirstStore) Upload(ctx context.Context, file io.Reader, folder, filename string) (string, error) {
+	if strings.HasPrefix(filename, "first") {
+		time.Sleep(50 * time.Millisecond)
+	}
+	return s.MemoryStore.Upload(ctx, file, folder, filename)
+}
+
+func TestUploadMultipleKeepsFileOrder(t *testing.T) {
+	store := slowFirstStore{NewMemoryStore()}
+	names := []string{"first.png", "second.png", "third.png"}
+
+	results, err := UploadMultiple(context.Background(), store, pngFiles(t, names), DefaultUploadOptions("products"))
+	if err != nil {
+		t.Fatalf("UploadMultiple() error = %v", err)
+	}
+
+	if len(results) != len(names) {
+		t.Fatalf("UploadMultiple() returned %d results, want %d", len(results), len(names))
+	}
+	for i, name := range names {
+		if results[i].AltText != name {
+			t.Errorf("result %d is %s, want %s", i, results[i].AltText, name)
+		}
+	}
+}
+
+func pngFiles(t *testing.T, names []string) []*multipart.FileHeader {
+	t.Helper()
+
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, image.NewRGBA(image.Rect(0, 0, 64, 48))); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, name := range names {
+		part, err := writer.CreateFormFile("images", name)
+		if err != nil {
+			t.Fatalf("create form file: %v", err)
+		}
+		part.Write(encoded.Bytes())
+	}
+	writer.Close()
+
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(32 << 20)
+	if err != nil {
+		t.Fatalf("read form: %v", err)
+	}
+	t.Cleanup(func() { form.RemoveAll() })
+
+	return form.File["images"]
+}
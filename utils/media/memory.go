@@ -0,0 +1,71 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+)
+
+const memoryBaseURL = "mem://media"
+
+// MemoryStore keeps uploads in memory; for tests and running without any storage
+type MemoryStore struct {
+	mu    sync.RWMutex
+	files map[string][]byte
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{files: make(map[string][]byte)}
+}
+
+func (s *MemoryStore) Upload(ctx context.Context, file io.Reader, folder, filename string) (string, error) {
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, file); err != nil {
+		return "", err
+	}
+
+	key := newObjectKey(folder, filename)
+
+	s.mu.Lock()
+	s.files[key] = buf.Bytes()
+	s.mu.Unlock()
+
+	return s.URL(key), nil
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, url string) error {
+	key, ok := keyFromURL(memoryBaseURL, url)
+	if !ok {
+		return nil
+	}
+
+	s.mu.Lock()
+	delete(s.files, key)
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *MemoryStore) URL(key string) string {
+	return memoryBaseURL + "/" + key
+}
+
+// Get returns the stored bytes for a URL returned by Upload
+func (s *MemoryStore) Get(url string) ([]byte, bool) {
+	key, ok := keyFromURL(memoryBaseURL, url)
+	if !ok {
+		return nil, false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	data, ok := s.files[key]
+	return data, ok
+}
+
+// Len is the number of stored files
+func (s *MemoryStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.files)
+}